@@ -9,6 +9,9 @@ import (
 )
 
 func TestPromise(t *testing.T) {
+	// Most specs check the outcome right after registering a callback, so
+	// callbacks are called synchronously unless a spec asks otherwise
+	SetDefaultScheduler(SyncScheduler)
 	RunSpecs(t, "Promise Suite")
 }
 
@@ -23,24 +26,21 @@ var _ = Describe("Promise", func() {
 			promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
 				done = true
 			})
-			promiseInternal := promiseInstance.(*promise)
 			assert.NotNil(t, promiseInstance)
 			assert.True(t, done)
-			assert.Equal(t, promiseInternal.state, pendingState)
+			assert.Equal(t, Pending, promiseInstance.State())
 		})
 
 		It("should create a resolved promise", func() {
 			promiseInstance := Resolve(nil)
-			promiseInternal := promiseInstance.(*promise)
-			assert.NotNil(t, promiseInternal)
-			assert.Equal(t, promiseInternal.state, fulfilledState)
+			assert.NotNil(t, promiseInstance)
+			assert.Equal(t, Fulfilled, promiseInstance.State())
 		})
 
 		It("should create a rejected promise", func() {
 			promiseInstance := Reject(nil)
-			promiseInternal := promiseInstance.(*promise)
-			assert.NotNil(t, promiseInternal)
-			assert.Equal(t, promiseInternal.state, rejectedState)
+			assert.NotNil(t, promiseInstance)
+			assert.Equal(t, Rejected, promiseInstance.State())
 		})
 
 		It("should reject if a rejected promise is resolved", func() {
@@ -97,13 +97,11 @@ var _ = Describe("Promise", func() {
 		})
 
 		It("should call Then callback when the original promise is resolved in the future", func() {
-			doneChan := make(chan bool, 1)
+			clock := NewFakeClock(time.Now())
 			promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
-				go func() {
-					time.Sleep(10 * time.Millisecond)
+				clock.AfterFunc(10*time.Millisecond, func() {
 					resolve("foo")
-					doneChan <- true
-				}()
+				})
 			})
 			promiseInstance.Then(func(i interface{}) interface{} {
 				assert.Equal(t, "foo", i)
@@ -112,17 +110,15 @@ var _ = Describe("Promise", func() {
 				assert.Equal(t, "bar", i)
 				return "baz"
 			})
-			<-doneChan
+			clock.Advance(10 * time.Millisecond)
 		})
 
 		It("should call Then callback when a previous callback returned a promise in the future", func() {
-			doneChan := make(chan bool, 1)
+			clock := NewFakeClock(time.Now())
 			promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
-				go func() {
-					time.Sleep(10 * time.Millisecond)
+				clock.AfterFunc(10*time.Millisecond, func() {
 					resolve("foo")
-					doneChan <- true
-				}()
+				})
 			})
 			promiseInstance.Then(func(i interface{}) interface{} {
 				assert.Equal(t, "foo", i)
@@ -131,17 +127,15 @@ var _ = Describe("Promise", func() {
 				assert.Equal(t, "bar", i)
 				return "baz"
 			})
-			<-doneChan
+			clock.Advance(10 * time.Millisecond)
 		})
 
 		It("should call Then callback when there is a chain of 10 callbacks in the future", func() {
-			doneChan := make(chan bool, 1)
+			clock := NewFakeClock(time.Now())
 			promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
-				go func() {
-					time.Sleep(10 * time.Millisecond)
+				clock.AfterFunc(10*time.Millisecond, func() {
 					resolve(2)
-					doneChan <- true
-				}()
+				})
 			})
 			currentPromise := promiseInstance
 			for i := 0; i < 10; i++ {
@@ -157,7 +151,7 @@ var _ = Describe("Promise", func() {
 				result = i.(int)
 				return nil
 			})
-			<-doneChan
+			clock.Advance(10 * time.Millisecond)
 			assert.Equal(t, 2048, result)
 		})
 
@@ -175,13 +169,11 @@ var _ = Describe("Promise", func() {
 		})
 
 		It("should call Then callback after Catch callback if an error value was returned in the future", func() {
-			doneChan := make(chan bool, 1)
+			clock := NewFakeClock(time.Now())
 			promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
-				go func() {
-					time.Sleep(10 * time.Millisecond)
+				clock.AfterFunc(10*time.Millisecond, func() {
 					resolve("foo")
-					doneChan <- true
-				}()
+				})
 			}).Then(func(i interface{}) interface{} {
 				assert.Equal(t, "foo", i)
 				return fmt.Errorf("Error!")
@@ -190,7 +182,7 @@ var _ = Describe("Promise", func() {
 			}).Then(func(i interface{}) interface{} {
 				return "bat"
 			})
-			<-doneChan
+			clock.Advance(10 * time.Millisecond)
 			promiseInternal := promiseInstance.(*promise)
 			assert.Equal(t, "bat", promiseInternal.resolveValue)
 		})
@@ -210,13 +202,11 @@ var _ = Describe("Promise", func() {
 		})
 
 		It("should call Then callback after Catch callback if a value was returned in the future", func() {
-			doneChan := make(chan bool, 1)
+			clock := NewFakeClock(time.Now())
 			promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
-				go func() {
-					time.Sleep(10 * time.Millisecond)
+				clock.AfterFunc(10*time.Millisecond, func() {
 					resolve("foo")
-					doneChan <- true
-				}()
+				})
 			}).Then(func(i interface{}) interface{} {
 				assert.Equal(t, "foo", i)
 				return "foo2"
@@ -226,11 +216,30 @@ var _ = Describe("Promise", func() {
 			}).Then(func(i interface{}) interface{} {
 				return "bat"
 			})
-			<-doneChan
+			clock.Advance(10 * time.Millisecond)
 			promiseInternal := promiseInstance.(*promise)
 			assert.Equal(t, "bat", promiseInternal.resolveValue)
 		})
 
+		It("should reject when a Then callback returns a rejected promise", func() {
+			done := false
+			Resolve("foo").Then(func(i interface{}) interface{} {
+				return Reject(fmt.Errorf("Error!"))
+			}).Catch(func(err error) interface{} {
+				assert.Equal(t, "Error!", err.Error())
+				done = true
+				return nil
+			})
+			assert.True(t, done)
+		})
+
+		It("should reject when a Then callback returns a promise rejected in the future", func() {
+			_, err := Await(Resolve("foo").Then(func(i interface{}) interface{} {
+				return DelayReject(10*time.Millisecond, fmt.Errorf("Error!"))
+			}))
+			assert.Equal(t, "Error!", err.Error())
+		})
+
 		It("should not call Then callback of a rejected promise", func() {
 			Reject(fmt.Errorf("foo")).Then(func(i interface{}) interface{} {
 				assert.Fail(t, "Should not call Then callback on rejected promise")
@@ -264,17 +273,15 @@ var _ = Describe("Promise", func() {
 		})
 
 		It("should call Catch on a rejected promise in the future", func() {
-			doneChan := make(chan bool, 1)
+			clock := NewFakeClock(time.Now())
 			done := false
 			promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
-				go func() {
-					time.Sleep(10 * time.Millisecond)
+				clock.AfterFunc(10*time.Millisecond, func() {
 					reject(fmt.Errorf("foo"))
-					doneChan <- true
-				}()
+				})
 			})
 
-			<-doneChan
+			clock.Advance(10 * time.Millisecond)
 			promiseInternal := promiseInstance.(*promise)
 			assert.Equal(t, "foo", promiseInternal.rejectValue.Error())
 			promiseInstance.Catch(func(e error) interface{} {
@@ -296,13 +303,11 @@ var _ = Describe("Promise", func() {
 		})
 
 		It("should call Catch on a rejected promise after Then in the future", func() {
-			doneChan := make(chan bool, 1)
+			clock := NewFakeClock(time.Now())
 			promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
-				go func() {
-					time.Sleep(10 * time.Millisecond)
+				clock.AfterFunc(10*time.Millisecond, func() {
 					reject(fmt.Errorf("foo"))
-					doneChan <- true
-				}()
+				})
 			}).Then(func(i interface{}) interface{} {
 				assert.Fail(t, "Should not be here")
 				return nil
@@ -310,7 +315,7 @@ var _ = Describe("Promise", func() {
 				return "foo"
 			})
 
-			<-doneChan
+			clock.Advance(10 * time.Millisecond)
 			promiseInternal := promiseInstance.(*promise)
 			assert.Equal(t, "foo", promiseInternal.resolveValue)
 		})
@@ -354,17 +359,15 @@ var _ = Describe("Promise", func() {
 		})
 
 		It("should call Finally on a rejected promise in the future", func() {
-			doneChan := make(chan bool, 1)
+			clock := NewFakeClock(time.Now())
 			done := false
 			promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
-				go func() {
-					time.Sleep(10 * time.Millisecond)
+				clock.AfterFunc(10*time.Millisecond, func() {
 					reject(fmt.Errorf("foo"))
-					doneChan <- true
-				}()
+				})
 			})
 
-			<-doneChan
+			clock.Advance(10 * time.Millisecond)
 			promiseInstance.Finally(func() error {
 				done = true
 				return nil
@@ -373,16 +376,14 @@ var _ = Describe("Promise", func() {
 		})
 
 		It("should call Finally on a resolved promise in the future", func() {
-			doneChan := make(chan bool, 1)
+			clock := NewFakeClock(time.Now())
 			done := false
 			promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
-				go func() {
-					time.Sleep(10 * time.Millisecond)
+				clock.AfterFunc(10*time.Millisecond, func() {
 					resolve("foo")
-					doneChan <- true
-				}()
+				})
 			})
-			<-doneChan
+			clock.Advance(10 * time.Millisecond)
 			promiseInstance.Finally(func() error {
 				done = true
 				return nil
@@ -391,16 +392,14 @@ var _ = Describe("Promise", func() {
 		})
 
 		It("should call Then after Finally with the original value", func() {
-			doneChan := make(chan bool, 1)
+			clock := NewFakeClock(time.Now())
 			done := false
 			promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
-				go func() {
-					time.Sleep(10 * time.Millisecond)
+				clock.AfterFunc(10*time.Millisecond, func() {
 					resolve("foo")
-					doneChan <- true
-				}()
+				})
 			})
-			<-doneChan
+			clock.Advance(10 * time.Millisecond)
 			promiseInstance.Finally(func() error {
 				return nil
 			}).Then(func(i interface{}) interface{} {
@@ -412,16 +411,14 @@ var _ = Describe("Promise", func() {
 		})
 
 		It("should call Catch after Finally with the original value", func() {
-			doneChan := make(chan bool, 1)
+			clock := NewFakeClock(time.Now())
 			done := false
 			promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
-				go func() {
-					time.Sleep(10 * time.Millisecond)
+				clock.AfterFunc(10*time.Millisecond, func() {
 					reject(fmt.Errorf("foo"))
-					doneChan <- true
-				}()
+				})
 			})
-			<-doneChan
+			clock.Advance(10 * time.Millisecond)
 			promiseInstance.Finally(func() error {
 				return nil
 			}).Catch(func(e error) interface{} {
@@ -433,16 +430,14 @@ var _ = Describe("Promise", func() {
 		})
 
 		It("should call Catch after Finally with the new error (Rejected)", func() {
-			doneChan := make(chan bool, 1)
+			clock := NewFakeClock(time.Now())
 			done := false
 			promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
-				go func() {
-					time.Sleep(10 * time.Millisecond)
+				clock.AfterFunc(10*time.Millisecond, func() {
 					reject(fmt.Errorf("foo"))
-					doneChan <- true
-				}()
+				})
 			})
-			<-doneChan
+			clock.Advance(10 * time.Millisecond)
 			promiseInstance.Finally(func() error {
 				return fmt.Errorf("bar")
 			}).Catch(func(e error) interface{} {
@@ -454,16 +449,14 @@ var _ = Describe("Promise", func() {
 		})
 
 		It("should call Catch after Finally with the new error (Resolved)", func() {
-			doneChan := make(chan bool, 1)
+			clock := NewFakeClock(time.Now())
 			done := false
 			promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
-				go func() {
-					time.Sleep(10 * time.Millisecond)
+				clock.AfterFunc(10*time.Millisecond, func() {
 					resolve("foo")
-					doneChan <- true
-				}()
+				})
 			})
-			<-doneChan
+			clock.Advance(10 * time.Millisecond)
 			promiseInstance.Finally(func() error {
 				return fmt.Errorf("bar")
 			}).Catch(func(e error) interface{} {
@@ -0,0 +1,1091 @@
+// Package aplus is a port of the Promises/A+ compliance tests
+// (https://github.com/promises-aplus/promises-aplus-tests) which can be run
+// against any implementation of the Promise interface.
+//
+// The specification is written for JavaScript, so a few sections are mapped
+// to Go:
+//   - Then and Catch take a single callback. Then(f) stands for then(f) and
+//     Catch(f) stands for then(undefined, f), so the "not a function" cases of
+//     2.2.1 and 2.2.7.3/2.2.7.4 are tested by the callback which is missing.
+//   - Throwing an exception (2.2.7.2, 2.3.3.3.4) is a panic, and the promise
+//     must be rejected with an error which errors.Is the panic value.
+//   - A thenable (2.3.3) is another implementation of the Promise interface.
+//     Its Then and Catch methods play the role of the two arguments of then,
+//     so the thenables of the tests start once both were called.
+//   - 2.2.5 (calling callbacks without this) has no meaning in Go.
+//
+// Where an implementation deliberately differs from the specification, it
+// lists the difference in Deviations. The tests of a listed deviation check
+// the documented behavior instead, or are skipped if that behavior cannot be
+// observed safely.
+package aplus
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gopromise "github.com/BorisKozo/gopromise"
+	"github.com/stretchr/testify/assert"
+)
+
+// Adapter creates promises of the implementation under test.
+type Adapter struct {
+	// Resolved returns a promise which is fulfilled with value
+	Resolved func(value interface{}) gopromise.Promise
+	// Rejected returns a promise which is rejected with err
+	Rejected func(err error) gopromise.Promise
+	// Deferred returns a pending promise and the functions which settle it
+	Deferred func() (promise gopromise.Promise, resolve func(interface{}), reject func(error))
+	// Deviations from the specification of the implementation
+	Deviations Deviations
+}
+
+// Deviations lists the parts of the specification an implementation
+// deliberately does not follow.
+type Deviations struct {
+	// SynchronousCallbacks means callbacks of a settled promise may be called
+	// before Then or Catch return, and callbacks of a pending promise may be
+	// called before resolve or reject return (2.2.4).
+	SynchronousCallbacks bool
+	// ErrorValuesReject means a callback which returns an error value, or a
+	// promise which is resolved with one, rejects with that error instead of
+	// being fulfilled with it (2.2.7.1, 2.3.4).
+	ErrorValuesReject bool
+	// PanicOnSecondSettle means resolving or rejecting a promise which is
+	// already settled panics instead of being ignored (2.1.2, 2.1.3).
+	PanicOnSecondSettle bool
+	// NoCycleDetection means a promise resolved with itself stays pending
+	// forever instead of being rejected (2.3.1).
+	NoCycleDetection bool
+	// UnrecoveredThenablePanics means a panic in the Then or Catch method of
+	// a thenable is not recovered, so it crashes the goroutine which resolved
+	// the promise instead of rejecting the promise (2.3.3.3.4).
+	UnrecoveredThenablePanics bool
+}
+
+const (
+	// settleTimeout is how long a test waits for a promise which should settle
+	settleTimeout = time.Second
+	// pendingDelay is how long a test waits to decide a promise stays pending
+	pendingDelay = 100 * time.Millisecond
+	// eventualDelay is how long an "eventually" settled promise takes
+	eventualDelay = 20 * time.Millisecond
+)
+
+var dummy = map[string]string{"dummy": "dummy"}
+var sentinel = map[string]string{"sentinel": "sentinel"}
+var errSentinel = errors.New("sentinel")
+
+// Run runs the compliance tests against the given adapter as subtests of t.
+func Run(t *testing.T, adapter Adapter) {
+	s := suite{adapter: adapter}
+	t.Run("2.1.2", s.testFulfilledState)
+	t.Run("2.1.3", s.testRejectedState)
+	t.Run("2.2.1", s.testOptionalArguments)
+	t.Run("2.2.2", s.testOnFulfilled)
+	t.Run("2.2.3", s.testOnRejected)
+	t.Run("2.2.4", s.testAsynchronousCallbacks)
+	t.Run("2.2.6", s.testMultipleCallbacks)
+	t.Run("2.2.7", s.testThenReturnsPromise)
+	t.Run("2.3.1", s.testSelfResolution)
+	t.Run("2.3.2", s.testPromiseResolution)
+	t.Run("2.3.3", s.testThenableResolution)
+	t.Run("2.3.4", s.testValueResolution)
+}
+
+type suite struct {
+	adapter Adapter
+}
+
+type outcome struct {
+	value    interface{}
+	err      error
+	rejected bool
+}
+
+// observe reports every outcome of the promise on the returned channel.
+func observe(promise gopromise.Promise) <-chan outcome {
+	outcomes := make(chan outcome, 2)
+	promise.Then(func(value interface{}) interface{} {
+		outcomes <- outcome{value: value}
+		return nil
+	})
+	promise.Catch(func(err error) interface{} {
+		outcomes <- outcome{err: err, rejected: true}
+		return nil
+	})
+	return outcomes
+}
+
+func expectFulfilled(t *testing.T, promise gopromise.Promise, value interface{}) {
+	t.Helper()
+	select {
+	case result := <-observe(promise):
+		if assert.False(t, result.rejected, "expected fulfillment but got rejection %v", result.err) {
+			assert.Equal(t, value, result.value)
+		}
+	case <-time.After(settleTimeout):
+		assert.Fail(t, "expected fulfillment but the promise is still pending")
+	}
+}
+
+func expectRejected(t *testing.T, promise gopromise.Promise, err error) {
+	t.Helper()
+	select {
+	case result := <-observe(promise):
+		if assert.True(t, result.rejected, "expected rejection but got fulfillment %v", result.value) {
+			assert.True(t, errors.Is(result.err, err), "expected %v but got %v", err, result.err)
+		}
+	case <-time.After(settleTimeout):
+		assert.Fail(t, "expected rejection but the promise is still pending")
+	}
+}
+
+func expectRejectedWithAnyError(t *testing.T, promise gopromise.Promise) {
+	t.Helper()
+	select {
+	case result := <-observe(promise):
+		if assert.True(t, result.rejected, "expected rejection but got fulfillment %v", result.value) {
+			assert.NotNil(t, result.err)
+		}
+	case <-time.After(settleTimeout):
+		assert.Fail(t, "expected rejection but the promise is still pending")
+	}
+}
+
+func expectPending(t *testing.T, promise gopromise.Promise) {
+	t.Helper()
+	select {
+	case result := <-observe(promise):
+		assert.Fail(t, "expected the promise to stay pending", "got %+v", result)
+	case <-time.After(pendingDelay):
+	}
+}
+
+// settleSafely calls settle and recovers the panic of a second settle if the
+// implementation is allowed to panic.
+func (s suite) settleSafely(t *testing.T, settle func()) {
+	t.Helper()
+	if s.adapter.Deviations.PanicOnSecondSettle {
+		assert.Panics(t, settle)
+		return
+	}
+	assert.NotPanics(t, settle)
+}
+
+// fulfilledScenarios returns promises fulfilled with value in the different
+// ways the specification tests: already, immediately and eventually.
+func (s suite) fulfilledScenarios(value interface{}) map[string]func() gopromise.Promise {
+	return map[string]func() gopromise.Promise{
+		"already-fulfilled": func() gopromise.Promise {
+			return s.adapter.Resolved(value)
+		},
+		"immediately-fulfilled": func() gopromise.Promise {
+			promise, resolve, _ := s.adapter.Deferred()
+			resolve(value)
+			return promise
+		},
+		"eventually-fulfilled": func() gopromise.Promise {
+			promise, resolve, _ := s.adapter.Deferred()
+			go func() {
+				time.Sleep(eventualDelay)
+				resolve(value)
+			}()
+			return promise
+		},
+	}
+}
+
+func (s suite) rejectedScenarios(err error) map[string]func() gopromise.Promise {
+	return map[string]func() gopromise.Promise{
+		"already-rejected": func() gopromise.Promise {
+			return s.adapter.Rejected(err)
+		},
+		"immediately-rejected": func() gopromise.Promise {
+			promise, _, reject := s.adapter.Deferred()
+			reject(err)
+			return promise
+		},
+		"eventually-rejected": func() gopromise.Promise {
+			promise, _, reject := s.adapter.Deferred()
+			go func() {
+				time.Sleep(eventualDelay)
+				reject(err)
+			}()
+			return promise
+		},
+	}
+}
+
+func (s suite) testFulfilledState(t *testing.T) {
+	t.Run("2.1.2.1 trying to fulfill then immediately reject", func(t *testing.T) {
+		promise, resolve, reject := s.adapter.Deferred()
+		resolve(dummy)
+		s.settleSafely(t, func() {
+			reject(errSentinel)
+		})
+		expectFulfilled(t, promise, dummy)
+	})
+
+	t.Run("2.1.2.1 trying to fulfill then reject, delayed", func(t *testing.T) {
+		promise, resolve, reject := s.adapter.Deferred()
+		resolve(dummy)
+		time.Sleep(eventualDelay)
+		s.settleSafely(t, func() {
+			reject(errSentinel)
+		})
+		expectFulfilled(t, promise, dummy)
+	})
+
+	t.Run("2.1.2.1 trying to fulfill twice", func(t *testing.T) {
+		promise, resolve, _ := s.adapter.Deferred()
+		resolve(dummy)
+		s.settleSafely(t, func() {
+			resolve(sentinel)
+		})
+		expectFulfilled(t, promise, dummy)
+	})
+}
+
+func (s suite) testRejectedState(t *testing.T) {
+	t.Run("2.1.3.1 trying to reject then immediately fulfill", func(t *testing.T) {
+		promise, resolve, reject := s.adapter.Deferred()
+		reject(errSentinel)
+		s.settleSafely(t, func() {
+			resolve(dummy)
+		})
+		expectRejected(t, promise, errSentinel)
+	})
+
+	t.Run("2.1.3.1 trying to reject then fulfill, delayed", func(t *testing.T) {
+		promise, resolve, reject := s.adapter.Deferred()
+		reject(errSentinel)
+		time.Sleep(eventualDelay)
+		s.settleSafely(t, func() {
+			resolve(dummy)
+		})
+		expectRejected(t, promise, errSentinel)
+	})
+
+	t.Run("2.1.3.1 trying to reject twice", func(t *testing.T) {
+		promise, _, reject := s.adapter.Deferred()
+		reject(errSentinel)
+		s.settleSafely(t, func() {
+			reject(errors.New("other"))
+		})
+		expectRejected(t, promise, errSentinel)
+	})
+}
+
+func (s suite) testOptionalArguments(t *testing.T) {
+	t.Run("2.2.1.1 Then without onRejected passes a rejection through", func(t *testing.T) {
+		for name, scenario := range s.rejectedScenarios(errSentinel) {
+			t.Run(name, func(t *testing.T) {
+				expectRejected(t, scenario().Then(func(interface{}) interface{} {
+					return nil
+				}), errSentinel)
+			})
+		}
+	})
+
+	t.Run("2.2.1.2 Catch without onFulfilled passes a value through", func(t *testing.T) {
+		for name, scenario := range s.fulfilledScenarios(sentinel) {
+			t.Run(name, func(t *testing.T) {
+				expectFulfilled(t, scenario().Catch(func(error) interface{} {
+					return nil
+				}), sentinel)
+			})
+		}
+	})
+}
+
+func (s suite) testOnFulfilled(t *testing.T) {
+	t.Run("2.2.2.1 it must be called after the promise is fulfilled, with the value", func(t *testing.T) {
+		for name, scenario := range s.fulfilledScenarios(sentinel) {
+			t.Run(name, func(t *testing.T) {
+				values := make(chan interface{}, 1)
+				scenario().Then(func(value interface{}) interface{} {
+					values <- value
+					return nil
+				})
+				select {
+				case value := <-values:
+					assert.Equal(t, sentinel, value)
+				case <-time.After(settleTimeout):
+					assert.Fail(t, "onFulfilled was not called")
+				}
+			})
+		}
+	})
+
+	t.Run("2.2.2.2 it must not be called before the promise is fulfilled", func(t *testing.T) {
+		promise, resolve, _ := s.adapter.Deferred()
+		var called int32
+		promise.Then(func(interface{}) interface{} {
+			atomic.StoreInt32(&called, 1)
+			return nil
+		})
+		time.Sleep(eventualDelay)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&called))
+		resolve(dummy)
+		expectFulfilled(t, promise, dummy)
+	})
+
+	t.Run("2.2.2.2 it must never be called if the promise is never fulfilled", func(t *testing.T) {
+		promise, _, _ := s.adapter.Deferred()
+		var called int32
+		promise.Then(func(interface{}) interface{} {
+			atomic.StoreInt32(&called, 1)
+			return nil
+		})
+		time.Sleep(pendingDelay)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&called))
+	})
+
+	t.Run("2.2.2.2 it must not be called when the promise is rejected", func(t *testing.T) {
+		for name, scenario := range s.rejectedScenarios(errSentinel) {
+			t.Run(name, func(t *testing.T) {
+				var called int32
+				promise := scenario()
+				promise.Then(func(interface{}) interface{} {
+					atomic.StoreInt32(&called, 1)
+					return nil
+				})
+				expectRejected(t, promise, errSentinel)
+				assert.Equal(t, int32(0), atomic.LoadInt32(&called))
+			})
+		}
+	})
+
+	t.Run("2.2.2.3 it must not be called more than once", func(t *testing.T) {
+		promise, resolve, reject := s.adapter.Deferred()
+		var calls int32
+		promise.Then(func(interface{}) interface{} {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		})
+		resolve(dummy)
+		s.settleSafely(t, func() {
+			resolve(dummy)
+		})
+		s.settleSafely(t, func() {
+			reject(errSentinel)
+		})
+		time.Sleep(pendingDelay)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
+
+func (s suite) testOnRejected(t *testing.T) {
+	t.Run("2.2.3.1 it must be called after the promise is rejected, with the reason", func(t *testing.T) {
+		for name, scenario := range s.rejectedScenarios(errSentinel) {
+			t.Run(name, func(t *testing.T) {
+				reasons := make(chan error, 1)
+				scenario().Catch(func(err error) interface{} {
+					reasons <- err
+					return nil
+				})
+				select {
+				case err := <-reasons:
+					assert.Equal(t, errSentinel, err)
+				case <-time.After(settleTimeout):
+					assert.Fail(t, "onRejected was not called")
+				}
+			})
+		}
+	})
+
+	t.Run("2.2.3.2 it must not be called before the promise is rejected", func(t *testing.T) {
+		promise, _, reject := s.adapter.Deferred()
+		var called int32
+		promise.Catch(func(error) interface{} {
+			atomic.StoreInt32(&called, 1)
+			return nil
+		})
+		time.Sleep(eventualDelay)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&called))
+		reject(errSentinel)
+		expectRejected(t, promise, errSentinel)
+	})
+
+	t.Run("2.2.3.2 it must not be called when the promise is fulfilled", func(t *testing.T) {
+		for name, scenario := range s.fulfilledScenarios(dummy) {
+			t.Run(name, func(t *testing.T) {
+				var called int32
+				promise := scenario()
+				promise.Catch(func(error) interface{} {
+					atomic.StoreInt32(&called, 1)
+					return nil
+				})
+				expectFulfilled(t, promise, dummy)
+				assert.Equal(t, int32(0), atomic.LoadInt32(&called))
+			})
+		}
+	})
+
+	t.Run("2.2.3.3 it must not be called more than once", func(t *testing.T) {
+		promise, resolve, reject := s.adapter.Deferred()
+		var calls int32
+		promise.Catch(func(error) interface{} {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		})
+		reject(errSentinel)
+		s.settleSafely(t, func() {
+			reject(errSentinel)
+		})
+		s.settleSafely(t, func() {
+			resolve(dummy)
+		})
+		time.Sleep(pendingDelay)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+}
+
+// checkAsynchronous registers a callback with register and checks that it is
+// not called before register returns.
+func (s suite) checkAsynchronous(t *testing.T, register func(callback func())) {
+	t.Helper()
+	returned := make(chan struct{})
+	calledAfterReturn := make(chan bool, 1)
+	register(func() {
+		if s.adapter.Deviations.SynchronousCallbacks {
+			select {
+			case <-returned:
+				calledAfterReturn <- true
+			default:
+				calledAfterReturn <- false
+			}
+			return
+		}
+		// A synchronous call blocks register, so only wait for a while
+		select {
+		case <-returned:
+			calledAfterReturn <- true
+		case <-time.After(pendingDelay):
+			calledAfterReturn <- false
+		}
+	})
+	close(returned)
+
+	select {
+	case afterReturn := <-calledAfterReturn:
+		if s.adapter.Deviations.SynchronousCallbacks {
+			assert.False(t, afterReturn, "expected a synchronous call (deviation SynchronousCallbacks)")
+		} else {
+			assert.True(t, afterReturn, "the callback was called before returning")
+		}
+	case <-time.After(settleTimeout):
+		assert.Fail(t, "the callback was not called")
+	}
+}
+
+func (s suite) testAsynchronousCallbacks(t *testing.T) {
+	t.Run("2.2.4 onFulfilled of an already fulfilled promise", func(t *testing.T) {
+		promise := s.adapter.Resolved(dummy)
+		s.checkAsynchronous(t, func(callback func()) {
+			promise.Then(func(interface{}) interface{} {
+				callback()
+				return nil
+			})
+		})
+	})
+
+	t.Run("2.2.4 onRejected of an already rejected promise", func(t *testing.T) {
+		promise := s.adapter.Rejected(errSentinel)
+		s.checkAsynchronous(t, func(callback func()) {
+			promise.Catch(func(error) interface{} {
+				callback()
+				return nil
+			})
+		})
+	})
+
+	t.Run("2.2.4 onFulfilled when fulfilling a pending promise", func(t *testing.T) {
+		promise, resolve, _ := s.adapter.Deferred()
+		promise.Then(func(interface{}) interface{} {
+			return nil
+		})
+		s.checkAsynchronous(t, func(callback func()) {
+			promise.Then(func(interface{}) interface{} {
+				callback()
+				return nil
+			})
+			resolve(dummy)
+		})
+	})
+
+	t.Run("2.2.4 onRejected when rejecting a pending promise", func(t *testing.T) {
+		promise, _, reject := s.adapter.Deferred()
+		s.checkAsynchronous(t, func(callback func()) {
+			promise.Catch(func(error) interface{} {
+				callback()
+				return nil
+			})
+			reject(errSentinel)
+		})
+	})
+}
+
+func (s suite) testMultipleCallbacks(t *testing.T) {
+	t.Run("2.2.6.1 onFulfilled callbacks are called in the order of their Then calls", func(t *testing.T) {
+		for name, scenario := range s.fulfilledScenarios(sentinel) {
+			t.Run(name, func(t *testing.T) {
+				promise := scenario()
+				mutex := sync.Mutex{}
+				order := []int{}
+				derived := []gopromise.Promise{}
+				for i := 0; i < 3; i++ {
+					index := i
+					derived = append(derived, promise.Then(func(value interface{}) interface{} {
+						assert.Equal(t, sentinel, value)
+						mutex.Lock()
+						order = append(order, index)
+						mutex.Unlock()
+						return index
+					}))
+				}
+				for index, promise := range derived {
+					expectFulfilled(t, promise, index)
+				}
+				mutex.Lock()
+				defer mutex.Unlock()
+				assert.Equal(t, []int{0, 1, 2}, order)
+			})
+		}
+	})
+
+	t.Run("2.2.6.1 a panicking onFulfilled does not stop the others", func(t *testing.T) {
+		promise := s.adapter.Resolved(dummy)
+		first := promise.Then(func(interface{}) interface{} {
+			panic(errSentinel)
+		})
+		second := promise.Then(func(interface{}) interface{} {
+			return sentinel
+		})
+		expectRejected(t, first, errSentinel)
+		expectFulfilled(t, second, sentinel)
+	})
+
+	t.Run("2.2.6.2 onRejected callbacks are called in the order of their Catch calls", func(t *testing.T) {
+		for name, scenario := range s.rejectedScenarios(errSentinel) {
+			t.Run(name, func(t *testing.T) {
+				promise := scenario()
+				mutex := sync.Mutex{}
+				order := []int{}
+				derived := []gopromise.Promise{}
+				for i := 0; i < 3; i++ {
+					index := i
+					derived = append(derived, promise.Catch(func(err error) interface{} {
+						assert.Equal(t, errSentinel, err)
+						mutex.Lock()
+						order = append(order, index)
+						mutex.Unlock()
+						return index
+					}))
+				}
+				for index, promise := range derived {
+					expectFulfilled(t, promise, index)
+				}
+				mutex.Lock()
+				defer mutex.Unlock()
+				assert.Equal(t, []int{0, 1, 2}, order)
+			})
+		}
+	})
+}
+
+func (s suite) testThenReturnsPromise(t *testing.T) {
+	t.Run("2.2.7 Then and Catch must return a promise", func(t *testing.T) {
+		promise := s.adapter.Resolved(dummy)
+		assert.NotNil(t, promise.Then(func(interface{}) interface{} {
+			return nil
+		}))
+		assert.NotNil(t, promise.Catch(func(error) interface{} {
+			return nil
+		}))
+	})
+
+	t.Run("2.2.7.2 a panic in onFulfilled rejects promise2", func(t *testing.T) {
+		for name, scenario := range s.fulfilledScenarios(dummy) {
+			t.Run(name, func(t *testing.T) {
+				expectRejected(t, scenario().Then(func(interface{}) interface{} {
+					panic(errSentinel)
+				}), errSentinel)
+			})
+		}
+	})
+
+	t.Run("2.2.7.2 a panic in onRejected rejects promise2", func(t *testing.T) {
+		for name, scenario := range s.rejectedScenarios(errors.New("original")) {
+			t.Run(name, func(t *testing.T) {
+				expectRejected(t, scenario().Catch(func(error) interface{} {
+					panic(errSentinel)
+				}), errSentinel)
+			})
+		}
+	})
+
+	t.Run("2.2.7.2 an error returned from onFulfilled", func(t *testing.T) {
+		promise2 := s.adapter.Resolved(dummy).Then(func(interface{}) interface{} {
+			return errSentinel
+		})
+		if s.adapter.Deviations.ErrorValuesReject {
+			expectRejected(t, promise2, errSentinel)
+		} else {
+			expectFulfilled(t, promise2, errSentinel)
+		}
+	})
+
+	t.Run("2.2.7.3 promise2 is fulfilled with the value when only onRejected is given", func(t *testing.T) {
+		for name, scenario := range s.fulfilledScenarios(sentinel) {
+			t.Run(name, func(t *testing.T) {
+				expectFulfilled(t, scenario().Catch(func(error) interface{} {
+					return dummy
+				}), sentinel)
+			})
+		}
+	})
+
+	t.Run("2.2.7.4 promise2 is rejected with the reason when only onFulfilled is given", func(t *testing.T) {
+		for name, scenario := range s.rejectedScenarios(errSentinel) {
+			t.Run(name, func(t *testing.T) {
+				expectRejected(t, scenario().Then(func(interface{}) interface{} {
+					return dummy
+				}), errSentinel)
+			})
+		}
+	})
+}
+
+// resolutionCases returns the ways a value x is fed to the resolution
+// procedure of a promise: returned from onFulfilled, returned from onRejected
+// and passed to resolve.
+func (s suite) resolutionCases(x func() interface{}) map[string]func() gopromise.Promise {
+	return map[string]func() gopromise.Promise{
+		"returned from onFulfilled": func() gopromise.Promise {
+			return s.adapter.Resolved(dummy).Then(func(interface{}) interface{} {
+				return x()
+			})
+		},
+		"returned from onRejected": func() gopromise.Promise {
+			return s.adapter.Rejected(errors.New("dummy")).Catch(func(error) interface{} {
+				return x()
+			})
+		},
+		"passed to resolve": func() gopromise.Promise {
+			promise, resolve, _ := s.adapter.Deferred()
+			resolve(x())
+			return promise
+		},
+	}
+}
+
+func (s suite) testSelfResolution(t *testing.T) {
+	cases := map[string]func() gopromise.Promise{
+		"returned from onFulfilled": func() gopromise.Promise {
+			promise, resolve, _ := s.adapter.Deferred()
+			var promise2 gopromise.Promise
+			ready := make(chan struct{})
+			promise2 = promise.Then(func(interface{}) interface{} {
+				<-ready
+				return promise2
+			})
+			close(ready)
+			resolve(dummy)
+			return promise2
+		},
+		"returned from onRejected": func() gopromise.Promise {
+			promise, _, reject := s.adapter.Deferred()
+			var promise2 gopromise.Promise
+			ready := make(chan struct{})
+			promise2 = promise.Catch(func(error) interface{} {
+				<-ready
+				return promise2
+			})
+			close(ready)
+			reject(errors.New("dummy"))
+			return promise2
+		},
+	}
+	for name, promise := range cases {
+		t.Run("2.3.1 a promise resolved with itself is rejected, "+name, func(t *testing.T) {
+			if s.adapter.Deviations.NoCycleDetection {
+				expectPending(t, promise())
+				return
+			}
+			expectRejectedWithAnyError(t, promise())
+		})
+	}
+}
+
+func (s suite) testPromiseResolution(t *testing.T) {
+	t.Run("2.3.2.1 a pending x keeps the promise pending", func(t *testing.T) {
+		for name, resolution := range s.resolutionCases(func() interface{} {
+			promise, _, _ := s.adapter.Deferred()
+			return promise
+		}) {
+			t.Run(name, func(t *testing.T) {
+				expectPending(t, resolution())
+			})
+		}
+	})
+
+	t.Run("2.3.2.2 a fulfilled x fulfills the promise with the same value", func(t *testing.T) {
+		for scenarioName, scenario := range s.fulfilledScenarios(sentinel) {
+			scenario := scenario
+			for name, resolution := range s.resolutionCases(func() interface{} {
+				return scenario()
+			}) {
+				t.Run(scenarioName+" "+name, func(t *testing.T) {
+					expectFulfilled(t, resolution(), sentinel)
+				})
+			}
+		}
+	})
+
+	t.Run("2.3.2.3 a rejected x rejects the promise with the same reason", func(t *testing.T) {
+		for scenarioName, scenario := range s.rejectedScenarios(errSentinel) {
+			scenario := scenario
+			for name, resolution := range s.resolutionCases(func() interface{} {
+				return scenario()
+			}) {
+				t.Run(scenarioName+" "+name, func(t *testing.T) {
+					expectRejected(t, resolution(), errSentinel)
+				})
+			}
+		}
+	})
+}
+
+// thenable is a foreign implementation of the Promise interface which does
+// not guard against misbehaving code. Like then(resolvePromise, rejectPromise)
+// in JavaScript, behavior runs once both Then and Catch were called, and every
+// call behavior makes to fulfill or reject is delivered to all the matching
+// callbacks, including the ones registered later.
+type thenable struct {
+	mutex       sync.Mutex
+	behavior    func(fulfill func(interface{}), reject func(error))
+	started     bool
+	outcomes    []outcome
+	onFulfilled []gopromise.PromiseResolveCallback
+	onRejected  []gopromise.PromiseRejectCallback
+	done        chan struct{}
+}
+
+func newThenable(behavior func(fulfill func(interface{}), reject func(error))) *thenable {
+	return &thenable{behavior: behavior, done: make(chan struct{})}
+}
+
+func (th *thenable) start() {
+	th.mutex.Lock()
+	if th.started || len(th.onFulfilled) == 0 || len(th.onRejected) == 0 {
+		th.mutex.Unlock()
+		return
+	}
+	th.started = true
+	th.mutex.Unlock()
+	th.behavior(th.fulfill, th.reject)
+}
+
+// record appends an outcome, the mutex must be held
+func (th *thenable) record(outcome outcome) {
+	if len(th.outcomes) == 0 {
+		close(th.done)
+	}
+	th.outcomes = append(th.outcomes, outcome)
+}
+
+func (th *thenable) fulfill(value interface{}) {
+	th.mutex.Lock()
+	th.record(outcome{value: value})
+	callbacks := append([]gopromise.PromiseResolveCallback{}, th.onFulfilled...)
+	th.mutex.Unlock()
+	for _, callback := range callbacks {
+		callback(value)
+	}
+}
+
+func (th *thenable) reject(err error) {
+	th.mutex.Lock()
+	th.record(outcome{err: err, rejected: true})
+	callbacks := append([]gopromise.PromiseRejectCallback{}, th.onRejected...)
+	th.mutex.Unlock()
+	for _, callback := range callbacks {
+		callback(err)
+	}
+}
+
+func (th *thenable) Then(callback gopromise.PromiseResolveCallback) gopromise.Promise {
+	th.mutex.Lock()
+	th.onFulfilled = append(th.onFulfilled, callback)
+	outcomes := append([]outcome{}, th.outcomes...)
+	th.mutex.Unlock()
+	for _, outcome := range outcomes {
+		if !outcome.rejected {
+			callback(outcome.value)
+		}
+	}
+	th.start()
+	return th
+}
+
+func (th *thenable) Catch(callback gopromise.PromiseRejectCallback) gopromise.Promise {
+	th.mutex.Lock()
+	th.onRejected = append(th.onRejected, callback)
+	outcomes := append([]outcome{}, th.outcomes...)
+	th.mutex.Unlock()
+	for _, outcome := range outcomes {
+		if outcome.rejected {
+			callback(outcome.err)
+		}
+	}
+	th.start()
+	return th
+}
+
+func (th *thenable) Finally(callback gopromise.PromiseFinallyCallback) gopromise.Promise {
+	return th
+}
+
+// State returns the state of the first outcome, like a promise would
+func (th *thenable) State() gopromise.State {
+	th.mutex.Lock()
+	defer th.mutex.Unlock()
+	if len(th.outcomes) == 0 {
+		return gopromise.Pending
+	}
+	if th.outcomes[0].rejected {
+		return gopromise.Rejected
+	}
+	return gopromise.Fulfilled
+}
+
+func (th *thenable) Done() <-chan struct{} {
+	return th.done
+}
+
+func (th *thenable) IsPending() bool {
+	return th.State() == gopromise.Pending
+}
+
+func (th *thenable) Peek() (interface{}, error, bool) {
+	th.mutex.Lock()
+	defer th.mutex.Unlock()
+	if len(th.outcomes) == 0 {
+		return nil, nil, false
+	}
+	return th.outcomes[0].value, th.outcomes[0].err, true
+}
+
+func (s suite) testThenableResolution(t *testing.T) {
+	fulfillingThenables := map[string]func(value interface{}) interface{}{
+		"synchronously fulfilling thenable": func(value interface{}) interface{} {
+			return newThenable(func(fulfill func(interface{}), reject func(error)) {
+				fulfill(value)
+			})
+		},
+		"asynchronously fulfilling thenable": func(value interface{}) interface{} {
+			return newThenable(func(fulfill func(interface{}), reject func(error)) {
+				go func() {
+					time.Sleep(eventualDelay)
+					fulfill(value)
+				}()
+			})
+		},
+	}
+
+	t.Run("2.3.3.3.1 a thenable fulfilled with y resolves the promise with y", func(t *testing.T) {
+		for thenableName, thenable := range fulfillingThenables {
+			thenable := thenable
+			for name, resolution := range s.resolutionCases(func() interface{} {
+				return thenable(sentinel)
+			}) {
+				t.Run(thenableName+" "+name, func(t *testing.T) {
+					expectFulfilled(t, resolution(), sentinel)
+				})
+			}
+		}
+	})
+
+	t.Run("2.3.3.3.1 a thenable fulfilled with another thenable is resolved recursively", func(t *testing.T) {
+		for outerName, outer := range fulfillingThenables {
+			outer := outer
+			for innerName, inner := range fulfillingThenables {
+				inner := inner
+				for name, resolution := range s.resolutionCases(func() interface{} {
+					return outer(inner(sentinel))
+				}) {
+					t.Run(outerName+" of "+innerName+" "+name, func(t *testing.T) {
+						expectFulfilled(t, resolution(), sentinel)
+					})
+				}
+			}
+		}
+	})
+
+	t.Run("2.3.3.3.1 a thenable fulfilled with a promise is resolved recursively", func(t *testing.T) {
+		for name, resolution := range s.resolutionCases(func() interface{} {
+			return fulfillingThenables["asynchronously fulfilling thenable"](s.adapter.Resolved(sentinel))
+		}) {
+			t.Run(name, func(t *testing.T) {
+				expectFulfilled(t, resolution(), sentinel)
+			})
+		}
+	})
+
+	rejectingThenables := map[string]func(err error) interface{}{
+		"synchronously rejecting thenable": func(err error) interface{} {
+			return newThenable(func(fulfill func(interface{}), reject func(error)) {
+				reject(err)
+			})
+		},
+		"asynchronously rejecting thenable": func(err error) interface{} {
+			return newThenable(func(fulfill func(interface{}), reject func(error)) {
+				go func() {
+					time.Sleep(eventualDelay)
+					reject(err)
+				}()
+			})
+		},
+	}
+
+	t.Run("2.3.3.3.2 a thenable rejected with r rejects the promise with r", func(t *testing.T) {
+		for thenableName, thenable := range rejectingThenables {
+			thenable := thenable
+			for name, resolution := range s.resolutionCases(func() interface{} {
+				return thenable(errSentinel)
+			}) {
+				t.Run(thenableName+" "+name, func(t *testing.T) {
+					expectRejected(t, resolution(), errSentinel)
+				})
+			}
+		}
+	})
+
+	misbehavingThenables := map[string]struct {
+		thenable  func() interface{}
+		fulfilled bool
+	}{
+		"fulfilling twice": {func() interface{} {
+			return newThenable(func(fulfill func(interface{}), reject func(error)) {
+				fulfill(sentinel)
+				fulfill(dummy)
+			})
+		}, true},
+		"fulfilling then rejecting": {func() interface{} {
+			return newThenable(func(fulfill func(interface{}), reject func(error)) {
+				fulfill(sentinel)
+				reject(errors.New("dummy"))
+			})
+		}, true},
+		"rejecting then fulfilling": {func() interface{} {
+			return newThenable(func(fulfill func(interface{}), reject func(error)) {
+				reject(errSentinel)
+				fulfill(dummy)
+			})
+		}, false},
+		"rejecting twice asynchronously": {func() interface{} {
+			return newThenable(func(fulfill func(interface{}), reject func(error)) {
+				go func() {
+					reject(errSentinel)
+					reject(errors.New("dummy"))
+				}()
+			})
+		}, false},
+	}
+
+	t.Run("2.3.3.3.3 the first call of a thenable takes precedence", func(t *testing.T) {
+		for thenableName, thenable := range misbehavingThenables {
+			for name, resolution := range s.resolutionCases(thenable.thenable) {
+				fulfilled := thenable.fulfilled
+				t.Run(thenableName+" "+name, func(t *testing.T) {
+					if fulfilled {
+						expectFulfilled(t, resolution(), sentinel)
+					} else {
+						expectRejected(t, resolution(), errSentinel)
+					}
+				})
+			}
+		}
+	})
+
+	t.Run("2.3.3.3.4 a panic in the thenable rejects the promise", func(t *testing.T) {
+		if s.adapter.Deviations.UnrecoveredThenablePanics {
+			t.Skip("deviation UnrecoveredThenablePanics")
+		}
+		for name, resolution := range s.resolutionCases(func() interface{} {
+			return newThenable(func(fulfill func(interface{}), reject func(error)) {
+				panic(errSentinel)
+			})
+		}) {
+			t.Run(name, func(t *testing.T) {
+				expectRejected(t, resolution(), errSentinel)
+			})
+		}
+	})
+
+	t.Run("2.3.3.3.4 a panic after the thenable fulfilled is ignored", func(t *testing.T) {
+		if s.adapter.Deviations.UnrecoveredThenablePanics {
+			t.Skip("deviation UnrecoveredThenablePanics")
+		}
+		for name, resolution := range s.resolutionCases(func() interface{} {
+			return newThenable(func(fulfill func(interface{}), reject func(error)) {
+				fulfill(sentinel)
+				panic(errors.New("dummy"))
+			})
+		}) {
+			t.Run(name, func(t *testing.T) {
+				expectFulfilled(t, resolution(), sentinel)
+			})
+		}
+	})
+
+	t.Run("2.3.3.4 a thenable which never settles keeps the promise pending", func(t *testing.T) {
+		for name, resolution := range s.resolutionCases(func() interface{} {
+			return newThenable(func(fulfill func(interface{}), reject func(error)) {})
+		}) {
+			t.Run(name, func(t *testing.T) {
+				expectPending(t, resolution())
+			})
+		}
+	})
+}
+
+func (s suite) testValueResolution(t *testing.T) {
+	values := map[string]interface{}{
+		"nil":    nil,
+		"int":    5,
+		"string": "foo",
+		"struct": struct{ Name string }{"foo"},
+		"map":    sentinel,
+		"slice":  []int{1, 2},
+	}
+	for valueName, value := range values {
+		value := value
+		for name, resolution := range s.resolutionCases(func() interface{} {
+			return value
+		}) {
+			expected := value
+			t.Run(fmt.Sprintf("2.3.4 %v %v", valueName, name), func(t *testing.T) {
+				expectFulfilled(t, resolution(), expected)
+			})
+		}
+	}
+
+	for name, resolution := range s.resolutionCases(func() interface{} {
+		return errSentinel
+	}) {
+		t.Run("2.3.4 error "+name, func(t *testing.T) {
+			if s.adapter.Deviations.ErrorValuesReject {
+				expectRejected(t, resolution(), errSentinel)
+			} else {
+				expectFulfilled(t, resolution(), errSentinel)
+			}
+		})
+	}
+}
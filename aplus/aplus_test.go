@@ -0,0 +1,47 @@
+package aplus
+
+import (
+	"testing"
+
+	gopromise "github.com/BorisKozo/gopromise"
+)
+
+// deviations of this library from the specification
+var deviations = Deviations{
+	ErrorValuesReject: true,
+}
+
+func adapterWithScheduler(scheduler gopromise.Scheduler, deviations Deviations) Adapter {
+	return Adapter{
+		Resolved: func(value interface{}) gopromise.Promise {
+			return gopromise.NewPromiseWithScheduler(scheduler, func(resolve func(interface{}), reject func(error)) {
+				resolve(value)
+			})
+		},
+		Rejected: func(err error) gopromise.Promise {
+			return gopromise.NewPromiseWithScheduler(scheduler, func(resolve func(interface{}), reject func(error)) {
+				reject(err)
+			})
+		},
+		Deferred: func() (gopromise.Promise, func(interface{}), func(error)) {
+			var resolvePromise func(interface{})
+			var rejectPromise func(error)
+			promise := gopromise.NewPromiseWithScheduler(scheduler, func(resolve func(interface{}), reject func(error)) {
+				resolvePromise = resolve
+				rejectPromise = reject
+			})
+			return promise, resolvePromise, rejectPromise
+		},
+		Deviations: deviations,
+	}
+}
+
+func TestMicrotaskScheduler(t *testing.T) {
+	Run(t, adapterWithScheduler(gopromise.NewMicrotaskScheduler(), deviations))
+}
+
+func TestSyncScheduler(t *testing.T) {
+	syncDeviations := deviations
+	syncDeviations.SynchronousCallbacks = true
+	Run(t, adapterWithScheduler(gopromise.SyncScheduler, syncDeviations))
+}
@@ -0,0 +1,97 @@
+package Promise
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// misbehavingPromise is a foreign Promise implementation which calls the
+// callbacks given to Then and Catch as many times as it is told to.
+type misbehavingPromise struct {
+	foreignPromise
+	then  func(callback PromiseResolveCallback)
+	catch func(callback PromiseRejectCallback)
+}
+
+func (m misbehavingPromise) Then(callback PromiseResolveCallback) Promise {
+	m.then(callback)
+	return m
+}
+
+func (m misbehavingPromise) Catch(callback PromiseRejectCallback) Promise {
+	m.catch(callback)
+	return m
+}
+
+var _ = Describe("Assimilation", func() {
+	var t = GinkgoT()
+	BeforeEach(func() {
+		t = GinkgoT()
+	})
+
+	misbehaving := func(then func(callback PromiseResolveCallback), catch func(callback PromiseRejectCallback)) Promise {
+		return misbehavingPromise{
+			foreignPromise: foreignPromise{inner: NewDeferred().Promise()},
+			then:           then,
+			catch:          catch,
+		}
+	}
+
+	It("should resolve with the value of a foreign promise", func() {
+		value, err := Await(Resolve(foreignPromise{inner: Resolve("foo")}))
+		assert.Nil(t, err)
+		assert.Equal(t, "foo", value)
+	})
+
+	It("should reject with the error of a foreign promise returned from a Then callback", func() {
+		_, err := Await(Resolve("foo").Then(func(i interface{}) interface{} {
+			return foreignPromise{inner: Reject(fmt.Errorf("Oh no"))}
+		}))
+		assert.Equal(t, "Oh no", err.Error())
+	})
+
+	It("should only use the first call of the callbacks", func() {
+		value, err := Await(Resolve(misbehaving(func(callback PromiseResolveCallback) {
+			callback("foo")
+			callback("bar")
+		}, func(callback PromiseRejectCallback) {
+			callback(fmt.Errorf("Oh no"))
+		})))
+		assert.Nil(t, err)
+		assert.Equal(t, "foo", value)
+	})
+
+	It("should reject when the Then of a foreign promise panics", func() {
+		_, err := Await(Resolve(misbehaving(func(callback PromiseResolveCallback) {
+			panic("Oh no")
+		}, func(callback PromiseRejectCallback) {})))
+		var panicError *PanicError
+		assert.True(t, errors.As(err, &panicError))
+		assert.Equal(t, "Oh no", panicError.Value)
+	})
+
+	It("should ignore a panic after the foreign promise called back", func() {
+		value, err := Await(Resolve(misbehaving(func(callback PromiseResolveCallback) {
+			callback("foo")
+		}, func(callback PromiseRejectCallback) {
+			panic("Oh no")
+		})))
+		assert.Nil(t, err)
+		assert.Equal(t, "foo", value)
+	})
+
+	It("should reject with a TypeError when a Then callback returns its own promise", func() {
+		var derived Promise
+		deferred := NewDeferred()
+		derived = deferred.Promise().Then(func(i interface{}) interface{} {
+			return derived
+		})
+		deferred.Resolve("foo")
+		_, err := Await(derived)
+		var typeError *TypeError
+		assert.True(t, errors.As(err, &typeError))
+	})
+})
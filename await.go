@@ -0,0 +1,34 @@
+package Promise
+
+import (
+	"context"
+	"time"
+)
+
+// Await blocks until the given promise is settled and returns its resolved
+// value or rejection error. Never await a promise on the goroutine that is
+// supposed to settle it, it will block forever.
+func Await(p Promise) (interface{}, error) {
+	return AwaitContext(context.Background(), p)
+}
+
+// AwaitContext is like Await but gives up when ctx is done, returning ctx.Err().
+func AwaitContext(ctx context.Context, p Promise) (interface{}, error) {
+	// The caller receives the error, so the rejection is handled
+	markHandled(p)
+	select {
+	case <-p.Done():
+		value, err, _ := p.Peek()
+		return value, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// AwaitTimeout is like Await but gives up after the given timeout, returning
+// context.DeadlineExceeded.
+func AwaitTimeout(p Promise, timeout time.Duration) (interface{}, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	return AwaitContext(ctx, p)
+}
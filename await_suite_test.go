@@ -0,0 +1,134 @@
+package Promise
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// foreignPromise is a minimal Promise implementation which is not a *promise.
+type foreignPromise struct {
+	inner Promise
+}
+
+func (f foreignPromise) Then(callback PromiseResolveCallback) Promise {
+	return f.inner.Then(callback)
+}
+
+func (f foreignPromise) Catch(callback PromiseRejectCallback) Promise {
+	return f.inner.Catch(callback)
+}
+
+func (f foreignPromise) Finally(callback PromiseFinallyCallback) Promise {
+	return f.inner.Finally(callback)
+}
+
+func (f foreignPromise) State() State {
+	return f.inner.State()
+}
+
+func (f foreignPromise) IsPending() bool {
+	return f.inner.IsPending()
+}
+
+func (f foreignPromise) Peek() (interface{}, error, bool) {
+	return f.inner.Peek()
+}
+
+func (f foreignPromise) Done() <-chan struct{} {
+	return f.inner.Done()
+}
+
+var _ = Describe("Await", func() {
+	var t = GinkgoT()
+	BeforeEach(func() {
+		t = GinkgoT()
+	})
+
+	Describe("Await", func() {
+		It("should return the value of a resolved promise", func() {
+			value, err := Await(Resolve("foo"))
+			assert.Nil(t, err)
+			assert.Equal(t, "foo", value)
+		})
+
+		It("should return the error of a rejected promise", func() {
+			value, err := Await(Reject(fmt.Errorf("Error")))
+			assert.Nil(t, value)
+			assert.Equal(t, "Error", err.Error())
+		})
+
+		It("should wait for a promise resolved in the future", func() {
+			value, err := Await(Delay(10*time.Millisecond, "foo"))
+			assert.Nil(t, err)
+			assert.Equal(t, "foo", value)
+		})
+
+		It("should wait for a promise rejected in the future", func() {
+			value, err := Await(DelayReject(10*time.Millisecond, fmt.Errorf("Error")))
+			assert.Nil(t, value)
+			assert.Equal(t, "Error", err.Error())
+		})
+
+		It("should wait for the end of a chain", func() {
+			value, err := Await(Run(func() interface{} {
+				return 2
+			}).Then(func(i interface{}) interface{} {
+				return Run(func() interface{} {
+					return i.(int) * 2
+				})
+			}))
+			assert.Nil(t, err)
+			assert.Equal(t, 4, value)
+		})
+
+		It("should await a foreign promise implementation", func() {
+			value, err := Await(foreignPromise{inner: Run(func() interface{} {
+				return "foo"
+			})})
+			assert.Nil(t, err)
+			assert.Equal(t, "foo", value)
+
+			value, err = Await(foreignPromise{inner: Reject(fmt.Errorf("Error"))})
+			assert.Nil(t, value)
+			assert.Equal(t, "Error", err.Error())
+		})
+	})
+
+	Describe("AwaitContext", func() {
+		It("should return the value when the promise settles first", func() {
+			value, err := AwaitContext(context.Background(), Resolve("foo"))
+			assert.Nil(t, err)
+			assert.Equal(t, "foo", value)
+		})
+
+		It("should return the context error when the context is canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {})
+			time.AfterFunc(10*time.Millisecond, cancel)
+			value, err := AwaitContext(ctx, promiseInstance)
+			assert.Nil(t, value)
+			assert.Equal(t, context.Canceled, err)
+		})
+	})
+
+	Describe("AwaitTimeout", func() {
+		It("should return the value when the promise settles in time", func() {
+			value, err := AwaitTimeout(Run(func() interface{} {
+				return "foo"
+			}), time.Second)
+			assert.Nil(t, err)
+			assert.Equal(t, "foo", value)
+		})
+
+		It("should return DeadlineExceeded when the promise does not settle in time", func() {
+			promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {})
+			value, err := AwaitTimeout(promiseInstance, 10*time.Millisecond)
+			assert.Nil(t, value)
+			assert.Equal(t, context.DeadlineExceeded, err)
+		})
+	})
+})
@@ -0,0 +1,80 @@
+package Promise
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ErrChannelClosed is the rejection error of a promise created with FromChan
+// or FromErrChan when the channel was closed before an item was received.
+var ErrChannelClosed = fmt.Errorf("Channel was closed before an item was received")
+
+// Result is the outcome of a promise sent by ToChan, either a Value or an Err.
+type Result struct {
+	Value interface{}
+	Err   error
+}
+
+// ToChan returns a channel which receives the outcome of the promise once it
+// is settled and is closed afterwards. The channel is buffered, so nothing
+// leaks if nobody receives from it.
+func ToChan(p Promise) <-chan Result {
+	results := make(chan Result, 1)
+	ThenOrCatch(p, func(value interface{}) interface{} {
+		results <- Result{Value: value}
+		close(results)
+		return nil
+	}, func(err error) interface{} {
+		results <- Result{Err: err}
+		close(results)
+		return nil
+	})
+	return results
+}
+
+// FromChan returns a promise which is resolved with the first item received
+// from ch, which may be a channel of any type. The promise is rejected with
+// ErrChannelClosed if ch is closed first. A goroutine waits on the channel
+// until an item is received or it is closed.
+func FromChan(ch interface{}) Promise {
+	return FromErrChan(ch, nil)
+}
+
+// FromErrChan is like FromChan but the promise is rejected with the first
+// error received from errCh if it comes before an item of valCh. A nil error
+// is ignored, so a function may send its result to valCh and its error
+// (which may be nil) to errCh.
+func FromErrChan(valCh interface{}, errCh <-chan error) Promise {
+	valValue := reflect.ValueOf(valCh)
+	if valValue.Kind() != reflect.Chan || valValue.Type().ChanDir()&reflect.RecvDir == 0 {
+		return Reject(fmt.Errorf("Expected a channel to receive from but got %T", valCh))
+	}
+	return NewPromise(func(resolve func(interface{}), reject func(error)) {
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: valValue},
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(errCh)},
+		}
+		go func() {
+			for {
+				chosen, received, ok := reflect.Select(cases)
+				if chosen == 0 {
+					if !ok {
+						reject(ErrChannelClosed)
+						return
+					}
+					resolve(received.Interface())
+					return
+				}
+				if !ok {
+					// A nil channel is never ready, so only valCh is left
+					cases[1].Chan = reflect.ValueOf((<-chan error)(nil))
+					continue
+				}
+				if err, _ := received.Interface().(error); err != nil {
+					reject(err)
+					return
+				}
+			}
+		}()
+	})
+}
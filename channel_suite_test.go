@@ -0,0 +1,115 @@
+package Promise
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("Channel", func() {
+	var t = GinkgoT()
+	BeforeEach(func() {
+		t = GinkgoT()
+	})
+
+	Describe("Done", func() {
+		It("should be closed when the promise is settled", func() {
+			deferred := NewDeferred()
+			select {
+			case <-deferred.Promise().Done():
+				assert.Fail(t, "should not be closed")
+			default:
+			}
+			deferred.Resolve("foo")
+			select {
+			case <-deferred.Promise().Done():
+			case <-time.After(time.Second):
+				assert.Fail(t, "should be closed")
+			}
+		})
+	})
+
+	Describe("ToChan", func() {
+		It("should receive the value of a resolved promise", func() {
+			results := ToChan(Resolve("foo"))
+			assert.Equal(t, Result{Value: "foo"}, <-results)
+			_, open := <-results
+			assert.False(t, open)
+		})
+
+		It("should receive the error of a promise rejected in the future", func() {
+			deferred := NewDeferred()
+			results := ToChan(deferred.Promise())
+			deferred.Reject(fmt.Errorf("Oh no"))
+			result := <-results
+			assert.Nil(t, result.Value)
+			assert.Equal(t, "Oh no", result.Err.Error())
+		})
+	})
+
+	Describe("FromChan", func() {
+		It("should resolve with the first item", func() {
+			ch := make(chan int, 2)
+			ch <- 1
+			ch <- 2
+			value, err := Await(FromChan(ch))
+			assert.Nil(t, err)
+			assert.Equal(t, 1, value)
+		})
+
+		It("should resolve with an item sent in the future", func() {
+			ch := make(chan string)
+			promiseInstance := FromChan(ch)
+			assert.True(t, promiseInstance.IsPending())
+			ch <- "foo"
+			value, err := Await(promiseInstance)
+			assert.Nil(t, err)
+			assert.Equal(t, "foo", value)
+		})
+
+		It("should reject if the channel is closed", func() {
+			ch := make(chan int)
+			close(ch)
+			_, err := Await(FromChan(ch))
+			assert.Equal(t, ErrChannelClosed, err)
+		})
+
+		It("should reject if it is not a channel", func() {
+			_, err := Await(FromChan(5))
+			assert.Equal(t, "Expected a channel to receive from but got int", err.Error())
+		})
+	})
+
+	Describe("FromErrChan", func() {
+		It("should resolve with the first item", func() {
+			valCh := make(chan int, 1)
+			errCh := make(chan error)
+			valCh <- 1
+			value, err := Await(FromErrChan(valCh, errCh))
+			assert.Nil(t, err)
+			assert.Equal(t, 1, value)
+		})
+
+		It("should reject with the first error", func() {
+			valCh := make(chan int)
+			errCh := make(chan error, 1)
+			errCh <- fmt.Errorf("Oh no")
+			_, err := Await(FromErrChan(valCh, errCh))
+			assert.Equal(t, "Oh no", err.Error())
+		})
+
+		It("should ignore a nil error and a closed error channel", func() {
+			valCh := make(chan int)
+			errCh := make(chan error, 1)
+			errCh <- nil
+			close(errCh)
+			promiseInstance := FromErrChan(valCh, errCh)
+			valCh <- 1
+			value, err := Await(promiseInstance)
+			assert.Nil(t, err)
+			assert.Equal(t, 1, value)
+		})
+	})
+})
@@ -0,0 +1,56 @@
+package Promise
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is the source of time used by the functions of this package which
+// wait, so tests can replace the real time with a fake one.
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+	// AfterFunc calls f on its own goroutine after the duration elapsed
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a function call scheduled with Clock.AfterFunc.
+type Timer interface {
+	// Stop prevents the call and reports whether it was prevented, false
+	// means the call already happened or the timer was already stopped
+	Stop() bool
+}
+
+// RealClock is the Clock of the time package.
+var RealClock Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+var defaultClockMutex sync.RWMutex
+var defaultClock = RealClock
+
+// SetDefaultClock sets the clock used by Delay, DelayReject, After,
+// WithTimeout, WithDeadline and Retry and returns the previous one.
+func SetDefaultClock(clock Clock) Clock {
+	defaultClockMutex.Lock()
+	defer defaultClockMutex.Unlock()
+	previous := defaultClock
+	defaultClock = clock
+	return previous
+}
+
+// DefaultClock returns the clock used by Delay, DelayReject, After,
+// WithTimeout, WithDeadline and Retry.
+func DefaultClock() Clock {
+	defaultClockMutex.RLock()
+	defer defaultClockMutex.RUnlock()
+	return defaultClock
+}
@@ -0,0 +1,102 @@
+package Promise
+
+import "context"
+
+// NewPromiseWithContext is like NewPromise but the promise is bound to ctx.
+// The executor receives a context derived from ctx so it can stop its work,
+// and the promise is rejected with the cause of ctx (usually ctx.Err()) if
+// ctx is done before the promise is settled. The context of the executor is
+// canceled once the promise is settled, or by WithTimeout and WithDeadline
+// when they give up on the promise. Promises derived from it with Then,
+// Catch, Finally and ThenOrCatch are bound to ctx as well. Calls to resolve
+// or reject after the promise was settled (for example by a cancellation)
+// are ignored.
+func NewPromiseWithContext(ctx context.Context, callback func(ctx context.Context, resolve func(interface{}), reject func(error))) Promise {
+	result := defaultPromise()
+	workContext, cancel := context.WithCancelCause(ctx)
+	result.ctx = ctx
+	result.cancelWork = cancel
+	result.watchContext(workContext)
+	if workContext.Err() != nil {
+		result.tryReject(context.Cause(workContext))
+		return result
+	}
+
+	result.execute(func(resolve func(interface{}), reject func(error)) {
+		callback(workContext, resolve, reject)
+	})
+	return result
+}
+
+// RunContext is like Run but fn receives ctx, and the returned promise is
+// rejected with the cause of ctx as soon as ctx is done, even if fn is still
+// running. Whatever fn returns after ctx is done is discarded.
+func RunContext(ctx context.Context, fn func(ctx context.Context) interface{}) Promise {
+	return NewPromiseWithContext(ctx, func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+		go func() {
+			result := callSafely(func() interface{} {
+				return fn(ctx)
+			})
+			if ctx.Err() != nil {
+				reject(context.Cause(ctx))
+				return
+			}
+			err, ok := result.(error)
+			if ok {
+				reject(err)
+			} else {
+				resolve(result)
+			}
+		}()
+	})
+}
+
+// newDerivedPromise is like NewPromise but the new promise uses the scheduler
+// of parent and is bound to its context, if it has one.
+func newDerivedPromise(parent Promise, callback func(resolve func(interface{}), reject func(error))) Promise {
+	result := derivePromise(parent)
+	result.execute(callback)
+	return result
+}
+
+// derivePromise creates a pending promise which uses the scheduler of parent
+// and is bound to its context, if it has one.
+func derivePromise(parent Promise) *promise {
+	result := defaultPromise()
+	if parentPromise, ok := parent.(*promise); ok {
+		result.scheduler = parentPromise.scheduler
+		result.waitingOn = parentPromise
+		if parentPromise.ctx != nil {
+			result.ctx = parentPromise.ctx
+			result.watchContext(parentPromise.ctx)
+		}
+	}
+	return result
+}
+
+// watchContext rejects the promise with the cause of ctx when ctx is done. It
+// must be called before the promise is shared with other goroutines.
+func (p *promise) watchContext(ctx context.Context) {
+	stop := context.AfterFunc(ctx, func() {
+		p.tryReject(context.Cause(ctx))
+	})
+	p.mutex.Lock()
+	if p.state != Pending {
+		p.mutex.Unlock()
+		stop()
+		return
+	}
+	p.stopContext = stop
+	p.mutex.Unlock()
+}
+
+// cancelContext cancels the context of the executor of a promise created with
+// NewPromiseWithContext with the given cause, unless the promise is settled.
+func (p *promise) cancelContext(cause error) {
+	p.mutex.Lock()
+	cancel := p.cancelWork
+	p.mutex.Unlock()
+	if cancel != nil {
+		cancel(cause)
+	}
+}
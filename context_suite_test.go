@@ -0,0 +1,207 @@
+package Promise
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("Context", func() {
+	var t = GinkgoT()
+	BeforeEach(func() {
+		t = GinkgoT()
+	})
+
+	pendingPromise := func() Promise {
+		return NewPromise(func(resolve func(interface{}), reject func(error)) {})
+	}
+
+	Describe("NewPromiseWithContext", func() {
+		It("should pass the context to the executor", func() {
+			ctx := context.WithValue(context.Background(), "key", "value")
+			var executorContext context.Context
+			NewPromiseWithContext(ctx, func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+				executorContext = ctx
+				resolve(nil)
+			})
+			assert.Equal(t, "value", executorContext.Value("key"))
+		})
+
+		It("should resolve like a regular promise", func() {
+			value, err := Await(NewPromiseWithContext(context.Background(), func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+				resolve("foo")
+			}))
+			assert.Nil(t, err)
+			assert.Equal(t, "foo", value)
+		})
+
+		It("should reject with the context error when the context is canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			promiseInstance := NewPromiseWithContext(ctx, func(ctx context.Context, resolve func(interface{}), reject func(error)) {})
+			cancel()
+			value, err := Await(promiseInstance)
+			assert.Nil(t, value)
+			assert.Equal(t, context.Canceled, err)
+		})
+
+		It("should reject immediately when the context is already done", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			called := false
+			promiseInstance := NewPromiseWithContext(ctx, func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+				called = true
+			})
+			assert.False(t, called)
+			assert.Equal(t, Rejected, promiseInstance.State())
+		})
+
+		It("should ignore resolve after the context was canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			var resolvePromise func(interface{})
+			promiseInstance := NewPromiseWithContext(ctx, func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+				resolvePromise = resolve
+			})
+			cancel()
+			_, err := Await(promiseInstance)
+			assert.Equal(t, context.Canceled, err)
+			assert.NotPanics(t, func() {
+				resolvePromise("foo")
+			})
+		})
+
+		It("should not reject when the context is canceled after the promise settled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			promiseInstance := NewPromiseWithContext(ctx, func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+				resolve("foo")
+			})
+			cancel()
+			value, err := Await(promiseInstance)
+			assert.Nil(t, err)
+			assert.Equal(t, "foo", value)
+		})
+		It("should cancel the context of the executor once the promise settled", func() {
+			var executorContext context.Context
+			NewPromiseWithContext(context.Background(), func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+				executorContext = ctx
+				assert.Nil(t, ctx.Err())
+				resolve("foo")
+			})
+			assert.Equal(t, context.Canceled, executorContext.Err())
+		})
+
+		It("should reject with the cause of the context", func() {
+			ctx, cancel := context.WithCancelCause(context.Background())
+			cause := fmt.Errorf("Shutting down")
+			promiseInstance := NewPromiseWithContext(ctx, func(ctx context.Context, resolve func(interface{}), reject func(error)) {})
+			cancel(cause)
+			_, err := Await(promiseInstance)
+			assert.Equal(t, cause, err)
+		})
+	})
+
+	Describe("RunContext", func() {
+		It("should run an async function and report the result", func() {
+			value, err := Await(RunContext(context.Background(), func(ctx context.Context) interface{} {
+				return "AAA"
+			}))
+			assert.Nil(t, err)
+			assert.Equal(t, "AAA", value)
+		})
+
+		It("should run an async function and reject if there was an error", func() {
+			value, err := Await(RunContext(context.Background(), func(ctx context.Context) interface{} {
+				return fmt.Errorf("Oh no")
+			}))
+			assert.Nil(t, value)
+			assert.Equal(t, "Oh no", err.Error())
+		})
+
+		It("should reject and signal the function when the context is canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			stopped := make(chan bool, 1)
+			promiseInstance := RunContext(ctx, func(ctx context.Context) interface{} {
+				<-ctx.Done()
+				stopped <- true
+				return "too late"
+			})
+			cancel()
+			value, err := Await(promiseInstance)
+			assert.Nil(t, value)
+			assert.Equal(t, context.Canceled, err)
+			assert.True(t, <-stopped)
+		})
+
+		It("should reject with the deadline error when the context times out", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+			defer cancel()
+			_, err := Await(RunContext(ctx, func(ctx context.Context) interface{} {
+				<-ctx.Done()
+				return nil
+			}))
+			assert.Equal(t, context.DeadlineExceeded, err)
+		})
+	})
+
+	Describe("Propagation", func() {
+		It("should reject a promise derived with Then when the context is canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			derived := NewPromiseWithContext(ctx, func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+				go resolve("foo")
+			}).Then(func(i interface{}) interface{} {
+				cancel()
+				return pendingPromise()
+			})
+			_, err := Await(derived)
+			assert.Equal(t, context.Canceled, err)
+		})
+
+		It("should reject a promise derived with Catch when the context is canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			derived := NewPromiseWithContext(ctx, func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+				go reject(fmt.Errorf("foo"))
+			}).Catch(func(err error) interface{} {
+				cancel()
+				return pendingPromise()
+			})
+			_, err := Await(derived)
+			assert.Equal(t, context.Canceled, err)
+		})
+
+		It("should reject a promise derived with Finally when the context is canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			derived := NewPromiseWithContext(ctx, func(ctx context.Context, resolve func(interface{}), reject func(error)) {}).Finally(func() error {
+				return nil
+			})
+			cancel()
+			_, err := Await(derived)
+			assert.Equal(t, context.Canceled, err)
+		})
+
+		It("should reject a promise derived with ThenOrCatch when the context is canceled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			derived := ThenOrCatch(NewPromiseWithContext(ctx, func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+				go resolve("foo")
+			}), func(i interface{}) interface{} {
+				cancel()
+				return pendingPromise()
+			}, func(err error) interface{} {
+				return nil
+			})
+			_, err := Await(derived)
+			assert.Equal(t, context.Canceled, err)
+		})
+
+		It("should not affect promises without a context", func() {
+			derived := Resolve("foo").Then(func(i interface{}) interface{} {
+				return "bar"
+			})
+			value, err := Await(derived)
+			assert.Nil(t, err)
+			assert.Equal(t, "bar", value)
+			assert.Nil(t, derived.(*promise).ctx)
+		})
+	})
+})
@@ -0,0 +1,58 @@
+package Promise
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ChainingCycleError is the rejection error of a promise which was resolved
+// with a promise that waits for it, directly or through other promises, so
+// none of them could ever be settled (A+ 2.3.1).
+type ChainingCycleError struct {
+	// Promises is the cycle, starting with the rejected promise and followed
+	// by the promise it was resolved with and the promises it waits for
+	Promises []Promise
+}
+
+func (e *ChainingCycleError) Error() string {
+	if len(e.Promises) == 1 {
+		return "TypeError: Chaining cycle detected for promise, it was resolved with itself"
+	}
+	return fmt.Sprintf("TypeError: Chaining cycle detected for promise, it waits for itself through %v promises", len(e.Promises))
+}
+
+// Unwrap returns a TypeError, the error JavaScript rejects with in this case.
+func (e *ChainingCycleError) Unwrap() error {
+	return &TypeError{Message: "Chaining cycle detected for promise"}
+}
+
+// adoptionMutex makes finding a cycle and recording the promise a promise
+// waits for atomic, so two promises cannot wait for each other unnoticed.
+var adoptionMutex sync.Mutex
+
+// waitFor records that the promise waits for inner, unless inner already
+// waits for the promise. In that case the cycle is returned and nothing is
+// recorded.
+func (p *promise) waitFor(inner *promise) []Promise {
+	adoptionMutex.Lock()
+	defer adoptionMutex.Unlock()
+	cycle := []Promise{p}
+	for current := inner; current != nil; current = current.currentlyWaitingOn() {
+		if current == p {
+			return cycle
+		}
+		cycle = append(cycle, current)
+	}
+	p.mutex.Lock()
+	if p.state == Pending {
+		p.waitingOn = inner
+	}
+	p.mutex.Unlock()
+	return nil
+}
+
+func (p *promise) currentlyWaitingOn() *promise {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.waitingOn
+}
@@ -0,0 +1,108 @@
+package Promise
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("Cycle", func() {
+	var t = GinkgoT()
+	BeforeEach(func() {
+		t = GinkgoT()
+	})
+
+	cycleErrorOf := func(p Promise) *ChainingCycleError {
+		_, err := AwaitTimeout(p, time.Second)
+		var cycleError *ChainingCycleError
+		assert.True(t, errors.As(err, &cycleError), "expected a ChainingCycleError but got %v", err)
+		return cycleError
+	}
+
+	It("should reject when a Then callback returns its own promise", func() {
+		deferred := NewDeferred()
+		var derived Promise
+		derived = deferred.Promise().Then(func(i interface{}) interface{} {
+			return derived
+		})
+		deferred.Resolve("foo")
+		cycleError := cycleErrorOf(derived)
+		assert.Equal(t, []Promise{derived}, cycleError.Promises)
+		assert.Equal(t, "TypeError: Chaining cycle detected for promise, it was resolved with itself", cycleError.Error())
+		var typeError *TypeError
+		assert.True(t, errors.As(cycleError, &typeError))
+	})
+
+	It("should reject when a Catch callback returns its own promise", func() {
+		deferred := NewDeferred()
+		var derived Promise
+		derived = deferred.Promise().Catch(func(err error) interface{} {
+			return derived
+		})
+		deferred.Reject(fmt.Errorf("foo"))
+		cycleErrorOf(derived)
+	})
+
+	It("should reject when a ThenOrCatch handler returns its own promise", func() {
+		deferred := NewDeferred()
+		var derived Promise
+		derived = ThenOrCatch(deferred.Promise(), func(i interface{}) interface{} {
+			return derived
+		}, func(err error) interface{} {
+			return nil
+		})
+		deferred.Resolve("foo")
+		cycleErrorOf(derived)
+	})
+
+	It("should reject when a Then callback returns a promise derived from its own with Finally", func() {
+		deferred := NewDeferred()
+		var finally Promise
+		derived := deferred.Promise().Then(func(i interface{}) interface{} {
+			return finally
+		})
+		finally = derived.Finally(func() error {
+			return nil
+		})
+		deferred.Resolve("foo")
+		cycleError := cycleErrorOf(derived)
+		assert.Equal(t, []Promise{derived, finally}, cycleError.Promises)
+		cycleErrorOf(finally)
+	})
+
+	It("should reject when two promises are resolved with each other", func() {
+		first := NewDeferred()
+		second := NewDeferred()
+		var a, b Promise
+		a = first.Promise().Then(func(i interface{}) interface{} {
+			return b
+		})
+		b = second.Promise().Then(func(i interface{}) interface{} {
+			return a
+		})
+		second.Resolve("foo")
+		first.Resolve("foo")
+		cycleError := cycleErrorOf(a)
+		assert.Equal(t, []Promise{a, b}, cycleError.Promises)
+		assert.Equal(t, "TypeError: Chaining cycle detected for promise, it waits for itself through 2 promises", cycleError.Error())
+		cycleErrorOf(b)
+	})
+
+	It("should not reject a promise resolved with a promise which waited for it before", func() {
+		deferred := NewDeferred()
+		first := deferred.Promise().Then(func(i interface{}) interface{} {
+			return "foo"
+		})
+		deferred.Resolve("foo")
+		value, err := Await(Resolve("bar").Then(func(i interface{}) interface{} {
+			return first.Then(func(i interface{}) interface{} {
+				return i
+			})
+		}))
+		assert.Nil(t, err)
+		assert.Equal(t, "foo", value)
+	})
+})
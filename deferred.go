@@ -0,0 +1,47 @@
+package Promise
+
+import "sync/atomic"
+
+// Deferred is a promise together with the functions which resolve or reject
+// it (like Promise.withResolvers in JavaScript), for code where the promise is
+// settled far from where it is created.
+type Deferred struct {
+	promise *promise
+	// resolved is set by the first call to Resolve or Reject, so later calls
+	// are ignored even while the promise follows a promise given to Resolve
+	resolved int32
+}
+
+// NewDeferred creates a Deferred with a pending promise.
+func NewDeferred() *Deferred {
+	return &Deferred{promise: defaultPromise()}
+}
+
+// Promise returns the promise which is settled by Resolve and Reject.
+func (d *Deferred) Promise() Promise {
+	return d.promise
+}
+
+// Resolve resolves the promise with value, like the resolve function of
+// NewPromise. Only the first call to Resolve or Reject has an effect, and it
+// is safe to call from any goroutine.
+func (d *Deferred) Resolve(value interface{}) {
+	if atomic.CompareAndSwapInt32(&d.resolved, 0, 1) {
+		d.promise.tryResolve(value)
+	}
+}
+
+// Reject rejects the promise with err, like the reject function of
+// NewPromise. Only the first call to Resolve or Reject has an effect, and it
+// is safe to call from any goroutine.
+func (d *Deferred) Reject(err error) {
+	if atomic.CompareAndSwapInt32(&d.resolved, 0, 1) {
+		d.promise.tryReject(err)
+	}
+}
+
+// IsSettled reports whether the promise was resolved or rejected. It is
+// false after Resolve was called with a promise which is still pending.
+func (d *Deferred) IsSettled() bool {
+	return !d.promise.IsPending()
+}
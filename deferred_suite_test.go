@@ -0,0 +1,102 @@
+package Promise
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("Deferred", func() {
+	var t = GinkgoT()
+	BeforeEach(func() {
+		t = GinkgoT()
+	})
+
+	It("should create a pending promise", func() {
+		deferred := NewDeferred()
+		assert.NotNil(t, deferred.Promise())
+		assert.False(t, deferred.IsSettled())
+		assert.Equal(t, Pending, deferred.Promise().State())
+	})
+
+	It("should resolve the promise", func() {
+		deferred := NewDeferred()
+		var result interface{}
+		deferred.Promise().Then(func(value interface{}) interface{} {
+			result = value
+			return nil
+		})
+		deferred.Resolve("foo")
+		assert.True(t, deferred.IsSettled())
+		assert.Equal(t, "foo", result)
+	})
+
+	It("should reject the promise", func() {
+		deferred := NewDeferred()
+		var result error
+		deferred.Promise().Catch(func(err error) interface{} {
+			result = err
+			return nil
+		})
+		deferred.Reject(fmt.Errorf("Oh no"))
+		assert.True(t, deferred.IsSettled())
+		assert.Equal(t, "Oh no", result.Error())
+	})
+
+	It("should ignore calls after the first one", func() {
+		deferred := NewDeferred()
+		deferred.Resolve("foo")
+		assert.NotPanics(t, func() {
+			deferred.Resolve("bar")
+			deferred.Reject(fmt.Errorf("Oh no"))
+		})
+		value, err := Await(deferred.Promise())
+		assert.Nil(t, err)
+		assert.Equal(t, "foo", value)
+	})
+
+	It("should follow a promise given to Resolve and ignore later calls", func() {
+		deferred := NewDeferred()
+		inner := NewDeferred()
+		deferred.Resolve(inner.Promise())
+		assert.False(t, deferred.IsSettled())
+		deferred.Resolve("bar")
+		deferred.Reject(fmt.Errorf("Oh no"))
+		assert.False(t, deferred.IsSettled())
+		inner.Resolve("foo")
+		value, err := Await(deferred.Promise())
+		assert.Nil(t, err)
+		assert.Equal(t, "foo", value)
+	})
+
+	It("should settle once when called from many goroutines", func() {
+		deferred := NewDeferred()
+		var calls int32
+		handled := ThenOrCatch(deferred.Promise(), func(value interface{}) interface{} {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		}, func(err error) interface{} {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		})
+		wg := sync.WaitGroup{}
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func(index int) {
+				defer wg.Done()
+				if index%2 == 0 {
+					deferred.Resolve(index)
+				} else {
+					deferred.Reject(fmt.Errorf("Error %v", index))
+				}
+			}(i)
+		}
+		wg.Wait()
+		Await(handled)
+		assert.True(t, deferred.IsSettled())
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+})
@@ -0,0 +1,36 @@
+package Promise
+
+import "time"
+
+// Delay returns a promise which is resolved with value after the duration
+// elapsed on the default clock.
+func Delay(d time.Duration, value interface{}) Promise {
+	clock := DefaultClock()
+	return NewPromise(func(resolve func(interface{}), reject func(error)) {
+		clock.AfterFunc(d, func() {
+			resolve(value)
+		})
+	})
+}
+
+// DelayReject returns a promise which is rejected with err after the duration
+// elapsed on the default clock.
+func DelayReject(d time.Duration, err error) Promise {
+	clock := DefaultClock()
+	return NewPromise(func(resolve func(interface{}), reject func(error)) {
+		clock.AfterFunc(d, func() {
+			reject(err)
+		})
+	})
+}
+
+// After returns a promise which is resolved with the current time of the
+// default clock once it reaches the given time.
+func After(t time.Time) Promise {
+	clock := DefaultClock()
+	return NewPromise(func(resolve func(interface{}), reject func(error)) {
+		clock.AfterFunc(t.Sub(clock.Now()), func() {
+			resolve(clock.Now())
+		})
+	})
+}
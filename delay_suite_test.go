@@ -0,0 +1,105 @@
+package Promise
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("Delay", func() {
+	var t = GinkgoT()
+	var clock *FakeClock
+	var previousClock Clock
+	BeforeEach(func() {
+		t = GinkgoT()
+		clock = NewFakeClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+		previousClock = SetDefaultClock(clock)
+	})
+	AfterEach(func() {
+		SetDefaultClock(previousClock)
+	})
+
+	Describe("Delay", func() {
+		It("should resolve with the value after the duration", func() {
+			var result interface{}
+			Delay(time.Second, "foo").Then(func(value interface{}) interface{} {
+				result = value
+				return nil
+			})
+			clock.Advance(999 * time.Millisecond)
+			assert.Nil(t, result)
+			clock.Advance(time.Millisecond)
+			assert.Equal(t, "foo", result)
+		})
+	})
+
+	Describe("DelayReject", func() {
+		It("should reject with the error after the duration", func() {
+			var result error
+			DelayReject(time.Second, fmt.Errorf("Oh no")).Catch(func(err error) interface{} {
+				result = err
+				return nil
+			})
+			clock.Advance(999 * time.Millisecond)
+			assert.Nil(t, result)
+			clock.Advance(time.Millisecond)
+			assert.Equal(t, "Oh no", result.Error())
+		})
+	})
+
+	Describe("After", func() {
+		It("should resolve with the time once the clock reaches it", func() {
+			at := clock.Now().Add(time.Minute)
+			var result interface{}
+			After(at).Then(func(value interface{}) interface{} {
+				result = value
+				return nil
+			})
+			clock.Advance(30 * time.Second)
+			assert.Nil(t, result)
+			clock.Advance(time.Minute)
+			assert.Equal(t, at, result)
+			assert.Equal(t, at.Add(30*time.Second), clock.Now())
+		})
+
+		It("should resolve when the clock advances if the time has passed", func() {
+			var result interface{}
+			After(clock.Now().Add(-time.Minute)).Then(func(value interface{}) interface{} {
+				result = value
+				return nil
+			})
+			clock.Advance(0)
+			assert.Equal(t, clock.Now(), result)
+		})
+	})
+
+	Describe("FakeClock", func() {
+		It("should call the timers in the order they are due", func() {
+			order := []int{}
+			clock.AfterFunc(2*time.Second, func() {
+				order = append(order, 2)
+			})
+			clock.AfterFunc(time.Second, func() {
+				order = append(order, 1)
+				clock.AfterFunc(500*time.Millisecond, func() {
+					order = append(order, 3)
+				})
+			})
+			clock.Advance(3 * time.Second)
+			assert.Equal(t, []int{1, 3, 2}, order)
+		})
+
+		It("should not call a stopped timer", func() {
+			timer := clock.AfterFunc(time.Second, func() {
+				assert.Fail(t, "should not be here")
+			})
+			assert.Equal(t, 1, clock.PendingTimers())
+			assert.True(t, timer.Stop())
+			assert.False(t, timer.Stop())
+			clock.Advance(time.Second)
+			assert.Equal(t, 0, clock.PendingTimers())
+		})
+	})
+})
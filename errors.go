@@ -0,0 +1,50 @@
+package Promise
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AggregateError is the rejection error of a promise which depends on several
+// promises and was rejected because one or more of them were rejected, like
+// Any, Map with CollectAll and Some. Errors holds the errors of the rejected
+// promises in the order of the given promises. The errors of Any and Some
+// are nil for promises which were rejected with nil.
+type AggregateError struct {
+	Errors []error
+	// Message describes why the errors were aggregated, the default is
+	// "All promises were rejected"
+	Message string
+}
+
+func (e *AggregateError) Error() string {
+	messages := make([]string, len(e.Errors))
+	for index, err := range e.Errors {
+		if err == nil {
+			messages[index] = "<nil>"
+			continue
+		}
+		messages[index] = err.Error()
+	}
+	message := e.Message
+	if message == "" {
+		message = "All promises were rejected"
+	}
+	return fmt.Sprintf("%v: [%v]", message, strings.Join(messages, ", "))
+}
+
+// Unwrap returns the individual errors so errors.Is and errors.As can find any
+// of them.
+func (e *AggregateError) Unwrap() []error {
+	return e.Errors
+}
+
+// TypeError is the rejection error of a promise which was resolved with a
+// value it cannot be resolved with, like a TypeError in JavaScript.
+type TypeError struct {
+	Message string
+}
+
+func (e *TypeError) Error() string {
+	return "TypeError: " + e.Message
+}
@@ -0,0 +1,91 @@
+package Promise
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// FakeClock is a Clock for tests whose time only moves when Advance is
+// called. Unlike RealClock, the functions of the timers which are due are
+// called in order on the goroutine which called Advance, so a test knows they
+// all returned when Advance returns.
+type FakeClock struct {
+	mutex  sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	clock *FakeClock
+	at    time.Time
+	f     func()
+}
+
+// NewFakeClock creates a fake clock whose current time is now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	timer := &fakeTimer{clock: c, at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+// Advance moves the time forward by d and calls the functions of the timers
+// which are due. A timer created by one of those functions is due relative to
+// the time of the timer which created it, so it is called too if it is due
+// before the new time.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	target := c.now.Add(d)
+	c.mutex.Unlock()
+	for {
+		c.mutex.Lock()
+		sort.SliceStable(c.timers, func(i, j int) bool {
+			return c.timers[i].at.Before(c.timers[j].at)
+		})
+		if len(c.timers) == 0 || c.timers[0].at.After(target) {
+			c.now = target
+			c.mutex.Unlock()
+			return
+		}
+		timer := c.timers[0]
+		c.timers[0] = nil
+		c.timers = c.timers[1:]
+		if timer.at.After(c.now) {
+			c.now = timer.at
+		}
+		c.mutex.Unlock()
+		timer.f()
+	}
+}
+
+// PendingTimers returns the number of timers which were neither called nor
+// stopped.
+func (c *FakeClock) PendingTimers() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.timers)
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mutex.Lock()
+	defer t.clock.mutex.Unlock()
+	for index, timer := range t.clock.timers {
+		if timer == t {
+			t.clock.timers = append(t.clock.timers[:index], t.clock.timers[index+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package Promise
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// LateSettleError describes a call to resolve or reject on a promise which
+// was already resolved or rejected. Such calls are ignored, but they usually
+// point to a bug, so a handler set with SetLateSettleHandler receives them.
+type LateSettleError struct {
+	// Attempted is "resolve" or "reject"
+	Attempted string
+	// Value is the value given to resolve
+	Value interface{}
+	// Err is the error given to reject
+	Err error
+	// State is the state of the promise, Fulfilled or Rejected, or Pending
+	// if it already follows a promise given to resolve
+	State State
+	// Stack is the stack trace of the goroutine which made the late call
+	Stack []byte
+}
+
+func (e *LateSettleError) Error() string {
+	return fmt.Sprintf("Trying to %v a promise which is not pending but %v", e.Attempted, e.State)
+}
+
+var lateSettleHandlerMutex sync.RWMutex
+var lateSettleHandler func(*LateSettleError)
+
+// SetLateSettleHandler enables the strict mode, in which handler is called
+// with every late call to resolve or reject of a NewPromise executor, and
+// returns the previous handler. The handler is called on the goroutine which
+// made the late call, so it may record the error, log it or panic with it.
+// A nil handler (the default) ignores late calls.
+func SetLateSettleHandler(handler func(*LateSettleError)) func(*LateSettleError) {
+	lateSettleHandlerMutex.Lock()
+	defer lateSettleHandlerMutex.Unlock()
+	previous := lateSettleHandler
+	lateSettleHandler = handler
+	return previous
+}
+
+// reportLateSettle calls the late settle handler, if there is one.
+func (p *promise) reportLateSettle(attempted string, value interface{}, err error) {
+	lateSettleHandlerMutex.RLock()
+	handler := lateSettleHandler
+	lateSettleHandlerMutex.RUnlock()
+	if handler == nil {
+		return
+	}
+	handler(&LateSettleError{
+		Attempted: attempted,
+		Value:     value,
+		Err:       err,
+		State:     p.State(),
+		Stack:     debug.Stack(),
+	})
+}
@@ -0,0 +1,105 @@
+package Promise
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("LateSettle", func() {
+	var t = GinkgoT()
+	var lateSettles []*LateSettleError
+	var previousHandler func(*LateSettleError)
+	BeforeEach(func() {
+		t = GinkgoT()
+		lateSettles = nil
+		previousHandler = SetLateSettleHandler(func(err *LateSettleError) {
+			lateSettles = append(lateSettles, err)
+		})
+	})
+	AfterEach(func() {
+		SetLateSettleHandler(previousHandler)
+	})
+
+	It("should keep the first value when resolved twice", func() {
+		value, err := Await(NewPromise(func(resolve func(interface{}), reject func(error)) {
+			resolve("foo")
+			resolve("bar")
+		}))
+		assert.Nil(t, err)
+		assert.Equal(t, "foo", value)
+		assert.Len(t, lateSettles, 1)
+		assert.Equal(t, "resolve", lateSettles[0].Attempted)
+		assert.Equal(t, "bar", lateSettles[0].Value)
+		assert.Equal(t, Fulfilled, lateSettles[0].State)
+		assert.Equal(t, "Trying to resolve a promise which is not pending but fulfilled", lateSettles[0].Error())
+		assert.NotEmpty(t, lateSettles[0].Stack)
+	})
+
+	It("should keep the first error when rejected after being rejected", func() {
+		_, err := Await(NewPromise(func(resolve func(interface{}), reject func(error)) {
+			reject(fmt.Errorf("foo"))
+			reject(fmt.Errorf("bar"))
+		}))
+		assert.Equal(t, "foo", err.Error())
+		assert.Len(t, lateSettles, 1)
+		assert.Equal(t, "reject", lateSettles[0].Attempted)
+		assert.Equal(t, "bar", lateSettles[0].Err.Error())
+		assert.Equal(t, Rejected, lateSettles[0].State)
+	})
+
+	It("should ignore calls after resolving with a pending promise", func() {
+		var resolveInner func(interface{})
+		inner := NewPromise(func(resolve func(interface{}), reject func(error)) {
+			resolveInner = resolve
+		})
+		promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
+			resolve(inner)
+			resolve("bar")
+			reject(fmt.Errorf("Oh no"))
+		})
+		assert.Len(t, lateSettles, 2)
+		assert.Equal(t, Pending, lateSettles[0].State)
+		resolveInner("foo")
+		value, err := Await(promiseInstance)
+		assert.Nil(t, err)
+		assert.Equal(t, "foo", value)
+	})
+
+	It("should not panic without a handler", func() {
+		SetLateSettleHandler(nil)
+		assert.NotPanics(t, func() {
+			NewPromise(func(resolve func(interface{}), reject func(error)) {
+				resolve("foo")
+				reject(fmt.Errorf("bar"))
+			})
+		})
+	})
+
+	It("should not report the first call after the context rejected the promise", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		var resolvePromise func(interface{})
+		promiseInstance := NewPromiseWithContext(ctx, func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+			resolvePromise = resolve
+		})
+		cancel()
+		_, err := Await(promiseInstance)
+		assert.Equal(t, context.Canceled, err)
+		resolvePromise("foo")
+		assert.Len(t, lateSettles, 0)
+		resolvePromise("bar")
+		assert.Len(t, lateSettles, 1)
+	})
+
+	It("should not report a single call", func() {
+		Await(NewPromise(func(resolve func(interface{}), reject func(error)) {
+			resolve("foo")
+		}))
+		Await(Resolve("foo").Then(func(i interface{}) interface{} {
+			return fmt.Errorf("bar")
+		}))
+		assert.Len(t, lateSettles, 0)
+	})
+})
@@ -0,0 +1,131 @@
+package Promise
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ErrorMode decides what Map does when one of the promises it created is
+// rejected.
+type ErrorMode int
+
+const (
+	// FailFast rejects with the first error, like All, and stops starting the
+	// items which were not started yet.
+	FailFast ErrorMode = iota
+	// CollectAll keeps going and rejects with an *AggregateError of all the
+	// errors once every item is settled.
+	CollectAll
+)
+
+// MapOptions configures Map. The zero value starts every item at once and
+// fails fast.
+type MapOptions struct {
+	// Concurrency is the maximum number of promises created by Map which may
+	// be pending at the same time, zero or less means no limit
+	Concurrency int
+	// ErrorMode decides what happens when one of the promises is rejected
+	ErrorMode ErrorMode
+}
+
+// Map calls fn for every item and returns a promise which is resolved with
+// the values of the promises fn returned, in the order of the items. Items are
+// started in order and no more than opts.Concurrency of them are pending at
+// the same time. A panic in fn rejects the promise of its item.
+func Map(items []interface{}, fn func(item interface{}, index int) Promise, opts MapOptions) Promise {
+	return NewPromise(func(resolve func(interface{}), reject func(error)) {
+		total := len(items)
+		results := make([]interface{}, total)
+		if total == 0 {
+			resolve(results)
+			return
+		}
+		errs := make([]error, total)
+		mutex := sync.Mutex{}
+		next := 0
+		running := 0
+		finished := 0
+		failed := false
+		launching := false
+
+		var launch func()
+		finish := func(index int, value interface{}, err error) {
+			mutex.Lock()
+			running--
+			finished++
+			if failed {
+				mutex.Unlock()
+				return
+			}
+			if err != nil && opts.ErrorMode == FailFast {
+				failed = true
+				mutex.Unlock()
+				reject(err)
+				return
+			}
+			results[index] = value
+			errs[index] = err
+			allFinished := finished == total
+			mutex.Unlock()
+			if allFinished {
+				settleMap(results, errs, resolve, reject)
+				return
+			}
+			launch()
+		}
+		start := func(index int) {
+			item := items[index]
+			promise := NewPromise(func(resolve func(interface{}), reject func(error)) {
+				resolve(callSafely(func() interface{} {
+					return fn(item, index)
+				}))
+			})
+			ThenOrCatch(promise, func(value interface{}) interface{} {
+				finish(index, value, nil)
+				return nil
+			}, func(err error) interface{} {
+				finish(index, nil, err)
+				return nil
+			})
+		}
+		// launch starts items until the limit is reached. Only one launch loop
+		// runs at a time so items which settle synchronously do not grow the
+		// stack, a nested call leaves the work to the running loop.
+		launch = func() {
+			mutex.Lock()
+			if launching {
+				mutex.Unlock()
+				return
+			}
+			launching = true
+			for !failed && next < total && (opts.Concurrency <= 0 || running < opts.Concurrency) {
+				index := next
+				next++
+				running++
+				mutex.Unlock()
+				start(index)
+				mutex.Lock()
+			}
+			launching = false
+			mutex.Unlock()
+		}
+		launch()
+	})
+}
+
+func settleMap(results []interface{}, errs []error, resolve func(interface{}), reject func(error)) {
+	var rejected []error
+	for _, err := range errs {
+		if err != nil {
+			rejected = append(rejected, err)
+		}
+	}
+	if len(rejected) == 0 {
+		resolve(results)
+		return
+	}
+	reject(&AggregateError{
+		Errors:  rejected,
+		Message: fmt.Sprintf("%v of %v items were rejected", len(rejected), len(errs)),
+	})
+}
@@ -0,0 +1,120 @@
+package Promise
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("Map", func() {
+	var t = GinkgoT()
+	BeforeEach(func() {
+		t = GinkgoT()
+	})
+
+	items := []interface{}{1, 2, 3, 4, 5}
+
+	It("should resolve with the values in the order of the items", func() {
+		deferreds := []*Deferred{}
+		promiseInstance := Map(items, func(item interface{}, index int) Promise {
+			deferred := NewDeferred()
+			deferreds = append(deferreds, deferred)
+			return deferred.Promise()
+		}, MapOptions{})
+		assert.Len(t, deferreds, 5)
+		for index := len(deferreds) - 1; index >= 0; index-- {
+			deferreds[index].Resolve(index * 10)
+		}
+		value, err := Await(promiseInstance)
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{0, 10, 20, 30, 40}, value)
+	})
+
+	It("should resolve with an empty slice when there are no items", func() {
+		value, err := Await(Map([]interface{}{}, func(item interface{}, index int) Promise {
+			return Resolve(item)
+		}, MapOptions{}))
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{}, value)
+	})
+
+	It("should not have more pending promises than the concurrency", func() {
+		deferreds := []*Deferred{}
+		promiseInstance := Map(items, func(item interface{}, index int) Promise {
+			deferred := NewDeferred()
+			deferreds = append(deferreds, deferred)
+			return deferred.Promise()
+		}, MapOptions{Concurrency: 2})
+		assert.Len(t, deferreds, 2)
+		deferreds[1].Resolve("b")
+		assert.Len(t, deferreds, 3)
+		deferreds[0].Resolve("a")
+		deferreds[2].Resolve("c")
+		assert.Len(t, deferreds, 5)
+		deferreds[4].Resolve("e")
+		assert.True(t, promiseInstance.IsPending())
+		deferreds[3].Resolve("d")
+		value, err := Await(promiseInstance)
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{"a", "b", "c", "d", "e"}, value)
+	})
+
+	It("should start items one after the other with a concurrency of one", func() {
+		order := []interface{}{}
+		value, err := Await(Map(items, func(item interface{}, index int) Promise {
+			order = append(order, item)
+			return Resolve(item.(int) * 2)
+		}, MapOptions{Concurrency: 1}))
+		assert.Nil(t, err)
+		assert.Equal(t, items, order)
+		assert.Equal(t, []interface{}{2, 4, 6, 8, 10}, value)
+	})
+
+	It("should reject with the first error and not start more items when failing fast", func() {
+		deferreds := []*Deferred{}
+		promiseInstance := Map(items, func(item interface{}, index int) Promise {
+			deferred := NewDeferred()
+			deferreds = append(deferreds, deferred)
+			return deferred.Promise()
+		}, MapOptions{Concurrency: 2})
+		deferreds[1].Reject(fmt.Errorf("Oh no"))
+		deferreds[0].Reject(fmt.Errorf("Too late"))
+		_, err := Await(promiseInstance)
+		assert.Equal(t, "Oh no", err.Error())
+		assert.Len(t, deferreds, 2)
+	})
+
+	It("should reject with all the errors when collecting them", func() {
+		value, err := Await(Map(items, func(item interface{}, index int) Promise {
+			if item.(int)%2 == 0 {
+				return Reject(fmt.Errorf("Error %v", item))
+			}
+			return Resolve(item)
+		}, MapOptions{ErrorMode: CollectAll}))
+		assert.Nil(t, value)
+		var aggregateError *AggregateError
+		assert.True(t, errors.As(err, &aggregateError))
+		assert.Len(t, aggregateError.Errors, 2)
+		assert.Equal(t, "2 of 5 items were rejected: [Error 2, Error 4]", err.Error())
+	})
+
+	It("should reject the promise of an item when fn panics", func() {
+		_, err := Await(Map(items, func(item interface{}, index int) Promise {
+			panic("Oh no")
+		}, MapOptions{}))
+		_, isPanic := err.(*PanicError)
+		assert.True(t, isPanic)
+	})
+
+	It("should run with goroutines", func() {
+		value, err := Await(Map(items, func(item interface{}, index int) Promise {
+			return Run(func() interface{} {
+				return item.(int) + index
+			})
+		}, MapOptions{Concurrency: 3}))
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{1, 3, 5, 7, 9}, value)
+	})
+})
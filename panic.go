@@ -0,0 +1,37 @@
+package Promise
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is the rejection error of a promise whose executor, callback or
+// Run function panicked.
+type PanicError struct {
+	// Value is the value that was passed to panic
+	Value interface{}
+	// Stack is the stack trace of the goroutine at the time of the panic
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("Promise callback panicked: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error so errors.Is and errors.As
+// can see through a PanicError.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// callSafely calls fn and returns a *PanicError instead of its result if it
+// panics.
+func callSafely(fn func() interface{}) (result interface{}) {
+	defer func() {
+		if value := recover(); value != nil {
+			result = &PanicError{Value: value, Stack: debug.Stack()}
+		}
+	}()
+	return fn()
+}
@@ -0,0 +1,131 @@
+package Promise
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("Panic", func() {
+	var t = GinkgoT()
+	BeforeEach(func() {
+		t = GinkgoT()
+	})
+
+	panicErrorOf := func(p Promise) *PanicError {
+		_, err := Await(p)
+		var panicError *PanicError
+		assert.True(t, errors.As(err, &panicError), "expected a PanicError but got %v", err)
+		return panicError
+	}
+
+	It("should reject when the executor panics", func() {
+		panicError := panicErrorOf(NewPromise(func(resolve func(interface{}), reject func(error)) {
+			panic("Oh no")
+		}))
+		assert.Equal(t, "Oh no", panicError.Value)
+		assert.Equal(t, "Promise callback panicked: Oh no", panicError.Error())
+		assert.NotEmpty(t, panicError.Stack)
+	})
+
+	It("should keep the value when the executor panics after resolving", func() {
+		value, err := Await(NewPromise(func(resolve func(interface{}), reject func(error)) {
+			resolve("foo")
+			panic("Oh no")
+		}))
+		assert.Nil(t, err)
+		assert.Equal(t, "foo", value)
+	})
+
+	It("should follow a pending promise when the executor panics after resolving with it", func() {
+		deferred := NewDeferred()
+		promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
+			resolve(deferred.Promise())
+			panic("Oh no")
+		})
+		assert.True(t, promiseInstance.IsPending())
+		deferred.Resolve("foo")
+		value, err := Await(promiseInstance)
+		assert.Nil(t, err)
+		assert.Equal(t, "foo", value)
+	})
+
+	It("should reject when a Then callback panics", func() {
+		panicError := panicErrorOf(Resolve("foo").Then(func(i interface{}) interface{} {
+			panic("Oh no")
+		}))
+		assert.Equal(t, "Oh no", panicError.Value)
+	})
+
+	It("should reject when a Then callback panics in the future", func() {
+		panicError := panicErrorOf(Run(func() interface{} {
+			return "foo"
+		}).Then(func(i interface{}) interface{} {
+			panic("Oh no")
+		}))
+		assert.Equal(t, "Oh no", panicError.Value)
+	})
+
+	It("should reject when a Catch callback panics", func() {
+		panicError := panicErrorOf(Reject(fmt.Errorf("foo")).Catch(func(err error) interface{} {
+			panic("Oh no")
+		}))
+		assert.Equal(t, "Oh no", panicError.Value)
+	})
+
+	It("should reject when a Catch callback panics in the future", func() {
+		panicError := panicErrorOf(Run(func() interface{} {
+			return fmt.Errorf("foo")
+		}).Catch(func(err error) interface{} {
+			panic("Oh no")
+		}))
+		assert.Equal(t, "Oh no", panicError.Value)
+	})
+
+	It("should reject when a Finally callback panics", func() {
+		panicError := panicErrorOf(Resolve("foo").Finally(func() error {
+			panic("Oh no")
+		}))
+		assert.Equal(t, "Oh no", panicError.Value)
+	})
+
+	It("should reject when a ThenOrCatch handler panics", func() {
+		panicError := panicErrorOf(ThenOrCatch(Reject(fmt.Errorf("foo")), func(i interface{}) interface{} {
+			return nil
+		}, func(err error) interface{} {
+			panic("Oh no")
+		}))
+		assert.Equal(t, "Oh no", panicError.Value)
+	})
+
+	It("should reject when a Run function panics", func() {
+		panicError := panicErrorOf(Run(func() interface{} {
+			panic("Oh no")
+		}))
+		assert.Equal(t, "Oh no", panicError.Value)
+	})
+
+	It("should be caught by Catch like any other error", func() {
+		done := false
+		Resolve("foo").Then(func(i interface{}) interface{} {
+			var values []int
+			return values[1]
+		}).Catch(func(err error) interface{} {
+			_, isPanic := err.(*PanicError)
+			assert.True(t, isPanic)
+			done = true
+			return nil
+		})
+		assert.True(t, done)
+	})
+
+	It("should unwrap a panic with an error value", func() {
+		original := fmt.Errorf("original")
+		_, err := Await(Resolve("foo").Then(func(i interface{}) interface{} {
+			panic(original)
+		}))
+		assert.True(t, errors.Is(err, original))
+	})
+})
@@ -0,0 +1,137 @@
+package Promise
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ErrPoolClosed is the rejection error of a function given to Pool.Run after
+// the pool was closed.
+var ErrPoolClosed = fmt.Errorf("Pool is closed")
+
+// Pool runs functions like Run but never runs more than a fixed number of
+// them at the same time. Functions given to Run when the pool is busy are
+// queued and started in order as the running ones return.
+type Pool struct {
+	mutex          sync.Mutex
+	maxConcurrency int
+	queue          []poolTask
+	active         int
+	closed         bool
+	idleCallbacks  []func(interface{})
+}
+
+type poolTask struct {
+	fn      func() interface{}
+	resolve func(interface{})
+	reject  func(error)
+}
+
+// NewPool creates a pool which runs up to maxConcurrency functions at the
+// same time. It panics if maxConcurrency is less than 1.
+func NewPool(maxConcurrency int) *Pool {
+	if maxConcurrency < 1 {
+		panic(fmt.Sprintf("Pool concurrency must be at least 1 but got %v", maxConcurrency))
+	}
+	return &Pool{maxConcurrency: maxConcurrency}
+}
+
+// Run is like the Run function but the function is started only when the pool
+// has room for it. The promise is rejected with ErrPoolClosed if the pool was
+// closed.
+func (pool *Pool) Run(fn func() interface{}) Promise {
+	return NewPromise(func(resolve func(interface{}), reject func(error)) {
+		task := poolTask{fn: fn, resolve: resolve, reject: reject}
+		pool.mutex.Lock()
+		if pool.closed {
+			pool.mutex.Unlock()
+			reject(ErrPoolClosed)
+			return
+		}
+		if pool.active < pool.maxConcurrency {
+			pool.active++
+			pool.mutex.Unlock()
+			go pool.work(task)
+			return
+		}
+		pool.queue = append(pool.queue, task)
+		pool.mutex.Unlock()
+	})
+}
+
+// Drain returns a promise which is resolved once no function is running and
+// the queue is empty. The pool keeps accepting new functions.
+func (pool *Pool) Drain() Promise {
+	return NewPromise(func(resolve func(interface{}), reject func(error)) {
+		pool.mutex.Lock()
+		if pool.active == 0 {
+			pool.mutex.Unlock()
+			resolve(nil)
+			return
+		}
+		pool.idleCallbacks = append(pool.idleCallbacks, resolve)
+		pool.mutex.Unlock()
+	})
+}
+
+// Close stops the pool from accepting new functions. Functions which are
+// already running or queued are not affected, and the returned promise is
+// resolved once all of them returned.
+func (pool *Pool) Close() Promise {
+	pool.mutex.Lock()
+	pool.closed = true
+	pool.mutex.Unlock()
+	return pool.Drain()
+}
+
+// QueueDepth returns the number of functions waiting for the pool to have
+// room for them.
+func (pool *Pool) QueueDepth() int {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	return len(pool.queue)
+}
+
+// Active returns the number of functions which are currently running.
+func (pool *Pool) Active() int {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	return pool.active
+}
+
+// work runs the given task and then the queued ones until the queue is empty,
+// so a pool never has more goroutines than its concurrency.
+func (pool *Pool) work(task poolTask) {
+	for {
+		task.run()
+
+		pool.mutex.Lock()
+		if len(pool.queue) == 0 {
+			pool.active--
+			var idleCallbacks []func(interface{})
+			if pool.active == 0 {
+				idleCallbacks = pool.idleCallbacks
+				pool.idleCallbacks = nil
+			}
+			pool.mutex.Unlock()
+			for _, resolve := range idleCallbacks {
+				resolve(nil)
+			}
+			return
+		}
+		task = pool.queue[0]
+		pool.queue[0] = poolTask{}
+		pool.queue = pool.queue[1:]
+		pool.mutex.Unlock()
+	}
+}
+
+func (task poolTask) run() {
+	result := callSafely(task.fn)
+	err, ok := result.(error)
+	if ok {
+		task.reject(err)
+	} else {
+		task.resolve(result)
+	}
+}
@@ -0,0 +1,155 @@
+package Promise
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("Pool", func() {
+	var t = GinkgoT()
+	BeforeEach(func() {
+		t = GinkgoT()
+	})
+
+	It("should run a function and report the result", func() {
+		pool := NewPool(1)
+		value, err := Await(pool.Run(func() interface{} {
+			return "AAA"
+		}))
+		assert.Nil(t, err)
+		assert.Equal(t, "AAA", value)
+	})
+
+	It("should run a function and reject if there was an error", func() {
+		pool := NewPool(1)
+		_, err := Await(pool.Run(func() interface{} {
+			return fmt.Errorf("Oh no")
+		}))
+		assert.Equal(t, "Oh no", err.Error())
+	})
+
+	It("should reject if the function panics", func() {
+		pool := NewPool(1)
+		_, err := Await(pool.Run(func() interface{} {
+			panic("Oh no")
+		}))
+		_, isPanic := err.(*PanicError)
+		assert.True(t, isPanic)
+		assert.Equal(t, 0, pool.Active())
+	})
+
+	It("should queue the functions which exceed the concurrency", func() {
+		pool := NewPool(2)
+		started := make(chan bool, 5)
+		release := make(chan bool)
+		var running, maxRunning int32
+		promises := []Promise{}
+		for i := 0; i < 5; i++ {
+			index := i
+			promises = append(promises, pool.Run(func() interface{} {
+				current := atomic.AddInt32(&running, 1)
+				for {
+					previous := atomic.LoadInt32(&maxRunning)
+					if current <= previous || atomic.CompareAndSwapInt32(&maxRunning, previous, current) {
+						break
+					}
+				}
+				started <- true
+				<-release
+				atomic.AddInt32(&running, -1)
+				return index
+			}))
+		}
+		assert.Equal(t, 2, pool.Active())
+		assert.Equal(t, 3, pool.QueueDepth())
+		<-started
+		<-started
+		close(release)
+
+		values, err := Await(All(promises))
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{0, 1, 2, 3, 4}, values)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&maxRunning))
+		_, err = Await(pool.Drain())
+		assert.Nil(t, err)
+		assert.Equal(t, 0, pool.Active())
+		assert.Equal(t, 0, pool.QueueDepth())
+	})
+
+	It("should start queued functions in order", func() {
+		pool := NewPool(1)
+		release := make(chan bool)
+		order := []int{}
+		pool.Run(func() interface{} {
+			<-release
+			return nil
+		})
+		promises := []Promise{}
+		for i := 0; i < 5; i++ {
+			index := i
+			promises = append(promises, pool.Run(func() interface{} {
+				order = append(order, index)
+				return nil
+			}))
+		}
+		close(release)
+		_, err := Await(All(promises))
+		assert.Nil(t, err)
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, order)
+	})
+
+	It("should resolve Drain when the pool is idle", func() {
+		pool := NewPool(1)
+		_, err := Await(pool.Drain())
+		assert.Nil(t, err)
+	})
+
+	It("should resolve Drain after the queued functions returned", func() {
+		pool := NewPool(1)
+		release := make(chan bool)
+		var finished int32
+		for i := 0; i < 3; i++ {
+			pool.Run(func() interface{} {
+				<-release
+				atomic.AddInt32(&finished, 1)
+				return nil
+			})
+		}
+		drained := pool.Drain()
+		close(release)
+		_, err := Await(drained)
+		assert.Nil(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&finished))
+	})
+
+	It("should finish the queued functions and reject new ones after Close", func() {
+		pool := NewPool(1)
+		release := make(chan bool)
+		var finished int32
+		for i := 0; i < 3; i++ {
+			pool.Run(func() interface{} {
+				<-release
+				atomic.AddInt32(&finished, 1)
+				return nil
+			})
+		}
+		closed := pool.Close()
+		_, err := Await(pool.Run(func() interface{} {
+			return nil
+		}))
+		assert.Equal(t, ErrPoolClosed, err)
+		close(release)
+		_, err = Await(closed)
+		assert.Nil(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&finished))
+	})
+
+	It("should panic if the concurrency is less than 1", func() {
+		assert.Panics(t, func() {
+			NewPool(0)
+		})
+	})
+})
@@ -1,10 +1,10 @@
 package Promise
 
-import "fmt"
-
-const pendingState = "pending"
-const fulfilledState = "fulfilled"
-const rejectedState = "rejected"
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
 
 type PromiseResolveCallback func(interface{}) interface{}
 type PromiseRejectCallback func(error) interface{}
@@ -14,6 +14,15 @@ type Promise interface {
 	Then(callback PromiseResolveCallback) Promise
 	Catch(callback PromiseRejectCallback) Promise
 	Finally(callback PromiseFinallyCallback) Promise
+	// State returns the current state of the promise
+	State() State
+	// IsPending reports whether the promise is still pending
+	IsPending() bool
+	// Peek returns the value or the error of the promise without waiting for
+	// it, settled is false while the promise is pending
+	Peek() (value interface{}, err error, settled bool)
+	// Done returns a channel which is closed when the promise is settled
+	Done() <-chan struct{}
 }
 
 type resolveRejector interface {
@@ -50,65 +59,79 @@ func (r rejectCallbackData) reject(err error) {
 	r.rejectFunc(err)
 }
 
+// settleCallback is a callback registered with Then or Catch. It receives the
+// outcome of the promise it was registered on and settles the promise which
+// was returned by Then or Catch.
+type settleCallback interface {
+	fulfilled(value interface{})
+	rejected(err error)
+}
+
+func (r resolveCallbackData) fulfilled(value interface{}) {
+	nextValue := callSafely(func() interface{} {
+		return r.callback(value)
+	})
+	resolveOrReject(nextValue, r)
+}
+
+func (r resolveCallbackData) rejected(err error) {
+	r.reject(err)
+}
+
+func (r rejectCallbackData) fulfilled(value interface{}) {
+	r.resolve(value)
+}
+
+func (r rejectCallbackData) rejected(err error) {
+	nextValue := callSafely(func() interface{} {
+		return r.callback(err)
+	})
+	resolveOrReject(nextValue, r)
+}
+
+// promise guards its state and pending callbacks with a mutex so it can be
+// resolved, rejected and subscribed to from multiple goroutines. Callbacks are
+// never invoked while the mutex is held.
 type promise struct {
-	state        string
+	mutex        sync.Mutex
+	state        State
 	resolveValue interface{}
 	rejectValue  error
-	nextResolved []resolveCallbackData
-	nextRejected []rejectCallbackData
+	callbacks    []settleCallback
+	done         chan struct{}
+	ctx          context.Context
+	stopContext  func() bool
+	cancelWork   context.CancelCauseFunc
+	scheduler    Scheduler
+	// handled, reportedUnhandled and tracker are used by the RejectionTracker
+	handled           bool
+	reportedUnhandled bool
+	tracker           *RejectionTracker
+	// waitingOn is the promise this pending promise waits for, its parent
+	// if it was created by Then or Catch, or the promise it was resolved with
+	waitingOn *promise
 }
 
 func (p *promise) Then(callback PromiseResolveCallback) Promise {
-	if p.state == fulfilledState {
-		nextValue := callback(p.resolveValue)
-		if innerPromise, ok := nextValue.(Promise); ok {
-			return innerPromise
-		}
-		if innerError, ok := nextValue.(error); ok {
-			return Reject(innerError)
-		}
-		return Resolve(nextValue)
-	}
-
-	if p.state == rejectedState {
-		return Reject(p.rejectValue)
-	}
-
 	callbackData := resolveCallbackData{callback: callback}
-	innerPromise := NewPromise(func(resolve func(interface{}), reject func(error)) {
+	innerPromise := newDerivedPromise(p, func(resolve func(interface{}), reject func(error)) {
 		callbackData.resolveFunc = resolve
 		callbackData.rejectFunc = reject
 	})
 	//callbackData.innerPromise = innerPromise
-	p.nextResolved = append(p.nextResolved, callbackData)
+	p.subscribe(callbackData)
 	return innerPromise
 }
 
 func (p *promise) Catch(callback PromiseRejectCallback) Promise {
-	if p.state == rejectedState {
-		nextValue := callback(p.rejectValue)
-		if innerPromise, ok := nextValue.(Promise); ok {
-			return innerPromise
-		}
-		if innerError, ok := nextValue.(error); ok {
-			return Reject(innerError)
-		}
-		return Resolve(nextValue)
-	}
-
-	if p.state == fulfilledState {
-		return Resolve(p.resolveValue)
-	}
-
 	callbackData := rejectCallbackData{callback: callback}
-	innerPromise := NewPromise(func(resolve func(interface{}), reject func(error)) {
+	innerPromise := newDerivedPromise(p, func(resolve func(interface{}), reject func(error)) {
 		callbackData.resolveFunc = resolve
 		callbackData.rejectFunc = reject
 	})
 	//callbackData.innerPromise = innerPromise
-	p.nextRejected = append(p.nextRejected, callbackData)
+	p.subscribe(callbackData)
 	return innerPromise
-
 }
 
 func (p *promise) Finally(callback PromiseFinallyCallback) Promise {
@@ -127,106 +150,231 @@ func (p *promise) Finally(callback PromiseFinallyCallback) Promise {
 	})
 }
 
+// resolveOrReject settles the promise returned by Then or Catch with the value
+// returned from the callback. A returned promise is passed on to resolve,
+// which follows both its resolution and its rejection.
 func resolveOrReject(value interface{}, resolveRejector resolveRejector) {
 	err, isError := value.(error)
 	if isError {
 		resolveRejector.reject(err)
 	} else {
-		innerPromise, isPromise := value.(Promise)
-		if isPromise {
-			innerPromise.Then(func(innerValue interface{}) interface{} {
-				resolveOrReject(innerValue, resolveRejector)
-				return nil
+		resolveRejector.resolve(value)
+	}
+}
+
+// subscribe queues the callback until the promise is settled, or dispatches it
+// right away if the promise is already settled.
+func (p *promise) subscribe(callback settleCallback) {
+	p.markHandled()
+	p.mutex.Lock()
+	if p.state == Pending {
+		p.callbacks = append(p.callbacks, callback)
+		p.mutex.Unlock()
+		return
+	}
+	state, resolveValue, rejectValue := p.state, p.resolveValue, p.rejectValue
+	p.mutex.Unlock()
+	p.dispatch([]settleCallback{callback}, state, resolveValue, rejectValue)
+}
+
+// dispatch hands the callbacks to the scheduler of the promise, one task per
+// callback in the order they were registered.
+func (p *promise) dispatch(callbacks []settleCallback, state State, value interface{}, err error) {
+	scheduler := p.currentScheduler()
+	for _, callback := range callbacks {
+		callback := callback
+		if state == Fulfilled {
+			scheduler.Schedule(func() {
+				callback.fulfilled(value)
 			})
 		} else {
-			resolveRejector.resolve(value)
+			scheduler.Schedule(func() {
+				callback.rejected(err)
+			})
 		}
 	}
 }
 
-func (p *promise) handleResolve(value interface{}) {
-	if p.state != pendingState {
-		panic(fmt.Errorf("Trying to resolve a promise which is not pending but %v", p.state))
+// settle moves a pending promise into the given state and hands back the
+// callbacks that were registered so far. Once settle returns, Then and Catch
+// observe the new state and no longer queue callbacks, so every callback is
+// invoked exactly once. The returned state is the one the promise was in
+// before the call; anything other than pending means nothing was changed.
+func (p *promise) settle(state State, value interface{}, err error) ([]settleCallback, State) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	previousState := p.state
+	if previousState != Pending {
+		return nil, previousState
+	}
+	p.state = state
+	p.resolveValue = value
+	p.rejectValue = err
+	callbacks := p.callbacks
+	p.callbacks = nil
+	p.waitingOn = nil
+	close(p.done)
+	// Both hold functions which refer back to the promise, a settled promise
+	// drops them so it can be garbage collected and reported to a
+	// RejectionTracker without a grace period
+	if p.stopContext != nil {
+		p.stopContext()
+		p.stopContext = nil
+	}
+	if p.cancelWork != nil {
+		p.cancelWork(nil)
+		p.cancelWork = nil
+	}
+	return callbacks, previousState
+}
+
+// tryResolve resolves the promise if it is still pending and reports whether
+// it was.
+func (p *promise) tryResolve(value interface{}) bool {
+	if p.State() != Pending {
+		return false
 	}
 	innerPromise, isPromise := value.(Promise)
 	if isPromise {
-		innerPromise.Then(func(innerValue interface{}) interface{} {
-			p.handleResolve(innerValue)
-			return nil
-		})
-		innerPromise.Catch(func(innerError error) interface{} {
-			p.handleReject(innerError)
-			return nil
-		})
-		return
+		if internal, ok := innerPromise.(*promise); ok {
+			if cycle := p.waitFor(internal); cycle != nil {
+				return p.tryReject(&ChainingCycleError{Promises: cycle})
+			}
+		}
+		p.adopt(innerPromise)
+		return true
 	}
 
 	if err, isError := value.(error); isError {
-		p.handleReject(err)
-		return
+		return p.tryReject(err)
 	}
 
-	p.state = fulfilledState
-	p.resolveValue = value
-	for _, callbackData := range p.nextResolved {
-		nextValue := callbackData.callback(value)
-		resolveOrReject(nextValue, callbackData)
+	callbacks, previousState := p.settle(Fulfilled, value, nil)
+	if previousState != Pending {
+		return false
 	}
+	p.dispatch(callbacks, Fulfilled, value, nil)
+	return true
+}
 
-	for _, callbackData := range p.nextRejected {
-		callbackData.resolve(value)
-	}
+// adoption settles a promise which was resolved with another promise of this
+// package along with it (A+ 2.3.2).
+type adoption struct {
+	p *promise
+}
+
+func (a adoption) fulfilled(value interface{}) {
+	a.p.tryResolve(value)
+}
 
+func (a adoption) rejected(err error) {
+	a.p.tryReject(err)
 }
 
-func (p *promise) handleReject(err error) {
-	if p.state != pendingState {
-		panic(fmt.Errorf("Trying to reject a promise which is not pending but %v", p.state))
+// adopt makes the promise follow innerPromise. A promise of this package is
+// subscribed to directly, other implementations are assimilated like an A+
+// thenable (2.3.3): only the first outcome counts, however many times and in
+// whatever order their callbacks are called, and a panic in their Then or
+// Catch rejects the promise unless an outcome was already received.
+func (p *promise) adopt(innerPromise Promise) {
+	if internal, ok := innerPromise.(*promise); ok {
+		internal.subscribe(adoption{p: p})
+		return
 	}
-	p.state = rejectedState
-	p.rejectValue = err
-	for _, callbackData := range p.nextRejected {
-		nextValue := callbackData.callback(err)
-		resolveOrReject(nextValue, callbackData)
+
+	var called int32
+	once := func(settle func()) {
+		if atomic.CompareAndSwapInt32(&called, 0, 1) {
+			settle()
+		}
 	}
+	panicError, isPanic := callSafely(func() interface{} {
+		innerPromise.Then(func(innerValue interface{}) interface{} {
+			once(func() {
+				p.tryResolve(innerValue)
+			})
+			return nil
+		})
+		innerPromise.Catch(func(innerError error) interface{} {
+			once(func() {
+				p.tryReject(innerError)
+			})
+			return nil
+		})
+		return nil
+	}).(*PanicError)
+	if isPanic {
+		once(func() {
+			p.tryReject(panicError)
+		})
+	}
+}
 
-	for _, callbackData := range p.nextResolved {
-		callbackData.reject(err)
+// tryReject rejects the promise if it is still pending and reports whether it
+// was.
+func (p *promise) tryReject(err error) bool {
+	callbacks, previousState := p.settle(Rejected, nil, err)
+	if previousState != Pending {
+		return false
 	}
+	p.dispatch(callbacks, Rejected, nil, err)
+	p.trackRejection(err)
+	return true
 }
 
 func defaultPromise() *promise {
 	return &promise{
-		state:        "pending",
+		state:        Pending,
 		resolveValue: nil,
 		rejectValue:  nil,
-		nextResolved: []resolveCallbackData{},
-		nextRejected: []rejectCallbackData{},
+		callbacks:    []settleCallback{},
+		done:         make(chan struct{}),
 	}
 }
 
 func NewPromise(callback func(resolve func(interface{}), reject func(error))) Promise {
 	result := defaultPromise()
+	result.execute(callback)
+	return result
+}
+
+func (p *promise) execute(callback func(resolve func(interface{}), reject func(error))) {
+	// Only the first call to resolve or reject counts, even if it resolved
+	// with a promise which is still pending
+	var called int32
 	resolveFunc := func(value interface{}) {
-		result.handleResolve(value)
+		if atomic.CompareAndSwapInt32(&called, 0, 1) && (p.tryResolve(value) || p.ctx != nil) {
+			// The context may reject the promise at any time, so a call after
+			// that is not a mistake of the caller
+			return
+		}
+		p.reportLateSettle("resolve", value, nil)
 	}
-
 	rejectFunc := func(err error) {
-		result.handleReject(err)
+		if atomic.CompareAndSwapInt32(&called, 0, 1) && (p.tryReject(err) || p.ctx != nil) {
+			return
+		}
+		p.reportLateSettle("reject", nil, err)
 	}
 
-	callback(resolveFunc, rejectFunc)
-	return result
+	// A panicking executor rejects the promise unless resolve or reject was
+	// already called
+	if panicError, isPanic := callSafely(func() interface{} {
+		callback(resolveFunc, rejectFunc)
+		return nil
+	}).(*PanicError); isPanic && atomic.CompareAndSwapInt32(&called, 0, 1) {
+		p.tryReject(panicError)
+	}
 }
 
 func Resolve(value interface{}) Promise {
 	result := defaultPromise()
-	result.handleResolve(value)
+	result.tryResolve(value)
 	return result
 }
 
 func Reject(err error) Promise {
 	result := defaultPromise()
-	result.handleReject(err)
+	result.tryReject(err)
 	return result
 }
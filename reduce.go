@@ -0,0 +1,61 @@
+package Promise
+
+import "sync"
+
+// Reduce folds the values of the promises into a single value. fn is called
+// with the accumulator, which starts as initial, and the value of each promise
+// in the order of the slice, as soon as that promise and the ones before it
+// are resolved. fn may return a promise which is waited for before the next
+// value is folded, or an error to reject. The promise is rejected as soon as
+// any of the promises is rejected.
+func Reduce(promises []Promise, fn func(accumulator interface{}, value interface{}, index int) interface{}, initial interface{}) Promise {
+	return NewPromise(func(resolve func(interface{}), reject func(error)) {
+		rejected := false
+		mutex := sync.Mutex{}
+		rejectOnce := func(err error) interface{} {
+			mutex.Lock()
+			if rejected {
+				mutex.Unlock()
+				return nil
+			}
+			rejected = true
+			mutex.Unlock()
+			reject(err)
+			return nil
+		}
+		current := Resolve(initial)
+		for index, promise := range promises {
+			innerIndex := index
+			innerPromise := promise
+			innerPromise.Catch(rejectOnce)
+			current = current.Then(func(accumulator interface{}) interface{} {
+				return innerPromise.Then(func(value interface{}) interface{} {
+					return fn(accumulator, value, innerIndex)
+				})
+			})
+		}
+		ThenOrCatch(current, func(value interface{}) interface{} {
+			resolve(value)
+			return nil
+		}, rejectOnce)
+	})
+}
+
+// Filter calls predicate for every item like Map, with no more than
+// concurrency of the promises it returns pending at the same time (zero or
+// less means no limit), and returns a promise which is resolved with the items
+// whose predicate resolved with true, in the order of the items. The promise
+// is rejected with the first error.
+func Filter(items []interface{}, predicate func(interface{}) Promise, concurrency int) Promise {
+	return Map(items, func(item interface{}, index int) Promise {
+		return predicate(item)
+	}, MapOptions{Concurrency: concurrency}).Then(func(values interface{}) interface{} {
+		results := []interface{}{}
+		for index, value := range values.([]interface{}) {
+			if value == true {
+				results = append(results, items[index])
+			}
+		}
+		return results
+	})
+}
@@ -0,0 +1,130 @@
+package Promise
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("Reduce", func() {
+	var t = GinkgoT()
+	BeforeEach(func() {
+		t = GinkgoT()
+	})
+
+	sum := func(accumulator interface{}, value interface{}, index int) interface{} {
+		return accumulator.(int) + value.(int)
+	}
+
+	Describe("Reduce", func() {
+		It("should fold the values in the order of the promises", func() {
+			first := NewDeferred()
+			second := NewDeferred()
+			folded := []interface{}{}
+			promiseInstance := Reduce([]Promise{first.Promise(), second.Promise(), Resolve("c")}, func(accumulator interface{}, value interface{}, index int) interface{} {
+				folded = append(folded, value)
+				return accumulator.(string) + value.(string)
+			}, "")
+			second.Resolve("b")
+			assert.Empty(t, folded)
+			first.Resolve("a")
+			value, err := Await(promiseInstance)
+			assert.Nil(t, err)
+			assert.Equal(t, "abc", value)
+			assert.Equal(t, []interface{}{"a", "b", "c"}, folded)
+		})
+
+		It("should fold a prefix before the rest of the promises are resolved", func() {
+			last := NewDeferred()
+			folded := 0
+			promiseInstance := Reduce([]Promise{Resolve(1), Resolve(2), last.Promise()}, func(accumulator interface{}, value interface{}, index int) interface{} {
+				folded++
+				return sum(accumulator, value, index)
+			}, 0)
+			assert.Equal(t, 2, folded)
+			last.Resolve(3)
+			value, err := Await(promiseInstance)
+			assert.Nil(t, err)
+			assert.Equal(t, 6, value)
+		})
+
+		It("should resolve with the initial value when there are no promises", func() {
+			value, err := Await(Reduce([]Promise{}, sum, 10))
+			assert.Nil(t, err)
+			assert.Equal(t, 10, value)
+		})
+
+		It("should wait for a promise returned by fn", func() {
+			value, err := Await(Reduce([]Promise{Resolve(1), Resolve(2)}, func(accumulator interface{}, value interface{}, index int) interface{} {
+				return Run(func() interface{} {
+					return accumulator.(int) + value.(int)*10
+				})
+			}, 0))
+			assert.Nil(t, err)
+			assert.Equal(t, 30, value)
+		})
+
+		It("should reject as soon as any promise is rejected", func() {
+			first := NewDeferred()
+			second := NewDeferred()
+			promiseInstance := Reduce([]Promise{first.Promise(), second.Promise()}, sum, 0)
+			second.Reject(fmt.Errorf("Oh no"))
+			assert.Equal(t, Rejected, promiseInstance.State())
+			_, err := Await(promiseInstance)
+			assert.Equal(t, "Oh no", err.Error())
+			first.Reject(fmt.Errorf("Too late"))
+			_, err = Await(promiseInstance)
+			assert.Equal(t, "Oh no", err.Error())
+		})
+
+		It("should reject when fn returns an error", func() {
+			_, err := Await(Reduce([]Promise{Resolve(1)}, func(accumulator interface{}, value interface{}, index int) interface{} {
+				return fmt.Errorf("Oh no")
+			}, 0))
+			assert.Equal(t, "Oh no", err.Error())
+		})
+	})
+
+	Describe("Filter", func() {
+		isEven := func(item interface{}) Promise {
+			return Resolve(item.(int)%2 == 0)
+		}
+
+		It("should keep the items whose predicate resolved with true", func() {
+			value, err := Await(Filter([]interface{}{1, 2, 3, 4, 5, 6}, isEven, 0))
+			assert.Nil(t, err)
+			assert.Equal(t, []interface{}{2, 4, 6}, value)
+		})
+
+		It("should resolve with an empty slice when nothing is kept", func() {
+			value, err := Await(Filter([]interface{}{1, 3}, isEven, 0))
+			assert.Nil(t, err)
+			assert.Equal(t, []interface{}{}, value)
+		})
+
+		It("should not have more pending predicates than the concurrency", func() {
+			deferreds := []*Deferred{}
+			promiseInstance := Filter([]interface{}{"a", "b", "c"}, func(item interface{}) Promise {
+				deferred := NewDeferred()
+				deferreds = append(deferreds, deferred)
+				return deferred.Promise()
+			}, 1)
+			assert.Len(t, deferreds, 1)
+			deferreds[0].Resolve(true)
+			assert.Len(t, deferreds, 2)
+			deferreds[1].Resolve(false)
+			deferreds[2].Resolve(true)
+			value, err := Await(promiseInstance)
+			assert.Nil(t, err)
+			assert.Equal(t, []interface{}{"a", "c"}, value)
+		})
+
+		It("should reject when a predicate is rejected", func() {
+			_, err := Await(Filter([]interface{}{1, 2}, func(item interface{}) Promise {
+				return Reject(fmt.Errorf("Oh no"))
+			}, 0))
+			assert.Equal(t, "Oh no", err.Error())
+		})
+	})
+})
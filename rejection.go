@@ -0,0 +1,130 @@
+package Promise
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// RejectionTracker reports rejected promises which nobody handles, like the
+// unhandledRejection and rejectionHandled events of Node.js. A rejection is
+// handled once a callback is registered on the promise with Then, Catch,
+// Finally or ThenOrCatch, or the promise is awaited with Await. Note that a
+// Then callback passes the rejection on to the promise it returns, which has
+// to be handled in turn.
+type RejectionTracker struct {
+	// OnUnhandledRejection is called with a rejected promise which was not
+	// handled before the grace period elapsed or it was garbage collected
+	OnUnhandledRejection func(p Promise, err error)
+	// OnRejectionHandled is called when a promise which was reported to
+	// OnUnhandledRejection is handled after all
+	OnRejectionHandled func(p Promise)
+	// GracePeriod is how long a rejected promise may stay unhandled. Zero
+	// means the promise is reported when it is garbage collected, which only
+	// happens once nothing can handle it anymore
+	GracePeriod time.Duration
+	// Clock is used to wait for the grace period, nil means DefaultClock()
+	Clock Clock
+}
+
+var rejectionTrackerMutex sync.RWMutex
+var rejectionTracker *RejectionTracker
+
+// SetRejectionTracker sets the tracker of promises which are not bound to a
+// context with a tracker of its own and returns the previous one. A nil
+// tracker (the default) does not track rejections.
+func SetRejectionTracker(tracker *RejectionTracker) *RejectionTracker {
+	rejectionTrackerMutex.Lock()
+	defer rejectionTrackerMutex.Unlock()
+	previous := rejectionTracker
+	rejectionTracker = tracker
+	return previous
+}
+
+type rejectionTrackerKey struct{}
+
+// WithRejectionTracker returns a context which makes the promises bound to it
+// (see NewPromiseWithContext) report their rejections to tracker instead of
+// the one set with SetRejectionTracker.
+func WithRejectionTracker(ctx context.Context, tracker *RejectionTracker) context.Context {
+	return context.WithValue(ctx, rejectionTrackerKey{}, tracker)
+}
+
+func (p *promise) rejectionTracker() *RejectionTracker {
+	if p.ctx != nil {
+		if tracker, ok := p.ctx.Value(rejectionTrackerKey{}).(*RejectionTracker); ok {
+			return tracker
+		}
+	}
+	rejectionTrackerMutex.RLock()
+	defer rejectionTrackerMutex.RUnlock()
+	return rejectionTracker
+}
+
+// trackRejection starts the grace period of a promise which was just
+// rejected, unless it is already handled.
+func (p *promise) trackRejection(err error) {
+	tracker := p.rejectionTracker()
+	if tracker == nil || tracker.OnUnhandledRejection == nil {
+		return
+	}
+	p.mutex.Lock()
+	if p.handled {
+		p.mutex.Unlock()
+		return
+	}
+	p.tracker = tracker
+	p.mutex.Unlock()
+
+	if tracker.GracePeriod <= 0 {
+		runtime.SetFinalizer(p, func(p *promise) {
+			p.reportUnhandled(err)
+		})
+		return
+	}
+	clock := tracker.Clock
+	if clock == nil {
+		clock = DefaultClock()
+	}
+	clock.AfterFunc(tracker.GracePeriod, func() {
+		p.reportUnhandled(err)
+	})
+}
+
+func (p *promise) reportUnhandled(err error) {
+	p.mutex.Lock()
+	if p.handled || p.reportedUnhandled {
+		p.mutex.Unlock()
+		return
+	}
+	p.reportedUnhandled = true
+	tracker := p.tracker
+	p.mutex.Unlock()
+	tracker.OnUnhandledRejection(p, err)
+}
+
+// markHandled records that the rejection of the promise, if any, is handled
+// and reports it if it was reported as unhandled before.
+func (p *promise) markHandled() {
+	p.mutex.Lock()
+	if p.handled {
+		p.mutex.Unlock()
+		return
+	}
+	p.handled = true
+	tracker := p.tracker
+	reported := p.reportedUnhandled
+	p.mutex.Unlock()
+	if reported && tracker.OnRejectionHandled != nil {
+		tracker.OnRejectionHandled(p)
+	}
+}
+
+// markHandled marks promises which the package creates for itself, and whose
+// rejection is passed on to another promise, as handled.
+func markHandled(p Promise) {
+	if internal, ok := p.(*promise); ok {
+		internal.markHandled()
+	}
+}
@@ -0,0 +1,190 @@
+package Promise
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingTracker records the calls of a RejectionTracker.
+type recordingTracker struct {
+	mutex     sync.Mutex
+	unhandled []error
+	handled   []Promise
+}
+
+func (r *recordingTracker) tracker(clock Clock, gracePeriod time.Duration) *RejectionTracker {
+	return &RejectionTracker{
+		OnUnhandledRejection: func(p Promise, err error) {
+			r.mutex.Lock()
+			defer r.mutex.Unlock()
+			r.unhandled = append(r.unhandled, err)
+		},
+		OnRejectionHandled: func(p Promise) {
+			r.mutex.Lock()
+			defer r.mutex.Unlock()
+			r.handled = append(r.handled, p)
+		},
+		GracePeriod: gracePeriod,
+		Clock:       clock,
+	}
+}
+
+func (r *recordingTracker) unhandledCount() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return len(r.unhandled)
+}
+
+var _ = Describe("RejectionTracker", func() {
+	var t = GinkgoT()
+	var clock *FakeClock
+	var recorder *recordingTracker
+	var previousTracker *RejectionTracker
+	BeforeEach(func() {
+		t = GinkgoT()
+		clock = NewFakeClock(time.Now())
+		recorder = &recordingTracker{}
+		previousTracker = SetRejectionTracker(recorder.tracker(clock, time.Second))
+	})
+	AfterEach(func() {
+		SetRejectionTracker(previousTracker)
+	})
+
+	It("should report a rejection which is not handled within the grace period", func() {
+		err := fmt.Errorf("Oh no")
+		Reject(err)
+		clock.Advance(999 * time.Millisecond)
+		assert.Empty(t, recorder.unhandled)
+		clock.Advance(time.Millisecond)
+		assert.Equal(t, []error{err}, recorder.unhandled)
+		clock.Advance(time.Minute)
+		assert.Len(t, recorder.unhandled, 1)
+	})
+
+	It("should not report a rejection which is handled within the grace period", func() {
+		promiseInstance := Reject(fmt.Errorf("Oh no"))
+		clock.Advance(500 * time.Millisecond)
+		promiseInstance.Catch(func(err error) interface{} {
+			return nil
+		})
+		clock.Advance(time.Second)
+		assert.Empty(t, recorder.unhandled)
+	})
+
+	It("should not report a rejection of a promise with a reject handler", func() {
+		deferred := NewDeferred()
+		deferred.Promise().Catch(func(err error) interface{} {
+			return nil
+		})
+		deferred.Reject(fmt.Errorf("Oh no"))
+		clock.Advance(time.Second)
+		assert.Empty(t, recorder.unhandled)
+	})
+
+	It("should report the promise returned by Then instead of the rejected one", func() {
+		var reported []Promise
+		SetRejectionTracker(&RejectionTracker{
+			OnUnhandledRejection: func(p Promise, err error) {
+				reported = append(reported, p)
+			},
+			GracePeriod: time.Second,
+			Clock:       clock,
+		})
+		derived := Reject(fmt.Errorf("Oh no")).Then(func(i interface{}) interface{} {
+			return nil
+		})
+		clock.Advance(time.Second)
+		assert.Equal(t, []Promise{derived}, reported)
+	})
+
+	It("should call OnRejectionHandled when a reported rejection is handled", func() {
+		promiseInstance := Reject(fmt.Errorf("Oh no"))
+		clock.Advance(time.Second)
+		assert.Len(t, recorder.unhandled, 1)
+		promiseInstance.Catch(func(err error) interface{} {
+			return nil
+		})
+		assert.Equal(t, []Promise{promiseInstance}, recorder.handled)
+		promiseInstance.Catch(func(err error) interface{} {
+			return nil
+		})
+		assert.Len(t, recorder.handled, 1)
+	})
+
+	It("should treat Await as a reject handler", func() {
+		promiseInstance := Reject(fmt.Errorf("Oh no"))
+		_, err := Await(promiseInstance)
+		assert.Equal(t, "Oh no", err.Error())
+		clock.Advance(time.Second)
+		assert.Empty(t, recorder.unhandled)
+	})
+
+	It("should not report the promises created by the package for itself", func() {
+		handle := func(p Promise) {
+			p.Catch(func(err error) interface{} {
+				return nil
+			})
+		}
+		handle(ThenOrCatch(Reject(fmt.Errorf("Oh no")), func(i interface{}) interface{} {
+			return nil
+		}, func(err error) interface{} {
+			return err
+		}))
+		handle(Reject(fmt.Errorf("Oh no")).Finally(func() error {
+			return nil
+		}))
+		handle(Resolve(Reject(fmt.Errorf("Oh no"))))
+		handle(Resolve("foo").Then(func(i interface{}) interface{} {
+			return Reject(fmt.Errorf("Oh no"))
+		}))
+		handle(All([]Promise{Reject(fmt.Errorf("Oh no")), Reject(fmt.Errorf("Oh no"))}))
+		handle(Any([]Promise{Reject(fmt.Errorf("Oh no"))}))
+		handle(WithTimeout(Reject(fmt.Errorf("Oh no")), time.Minute))
+		<-ToChan(Reject(fmt.Errorf("Oh no")))
+		clock.Advance(time.Minute)
+		assert.Empty(t, recorder.unhandled)
+	})
+
+	It("should report to the tracker of the context", func() {
+		scoped := &recordingTracker{}
+		ctx := WithRejectionTracker(context.Background(), scoped.tracker(clock, time.Second))
+		NewPromiseWithContext(ctx, func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+			reject(fmt.Errorf("Oh no"))
+		})
+		clock.Advance(time.Second)
+		assert.Len(t, scoped.unhandled, 1)
+		assert.Empty(t, recorder.unhandled)
+	})
+
+	It("should report to the tracker of the context when the promise is garbage collected without a grace period", func() {
+		scoped := &recordingTracker{}
+		ctx := WithRejectionTracker(context.Background(), scoped.tracker(nil, 0))
+		NewPromiseWithContext(ctx, func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+			reject(fmt.Errorf("Oh no"))
+		})
+		deadline := time.Now().Add(time.Second)
+		for scoped.unhandledCount() == 0 && time.Now().Before(deadline) {
+			runtime.GC()
+			time.Sleep(time.Millisecond)
+		}
+		assert.Equal(t, 1, scoped.unhandledCount())
+		assert.Empty(t, recorder.unhandled)
+	})
+
+	It("should report a rejection when the promise is garbage collected without a grace period", func() {
+		SetRejectionTracker(recorder.tracker(nil, 0))
+		Reject(fmt.Errorf("Oh no"))
+		deadline := time.Now().Add(time.Second)
+		for recorder.unhandledCount() == 0 && time.Now().Before(deadline) {
+			runtime.GC()
+			time.Sleep(time.Millisecond)
+		}
+		assert.Equal(t, 1, recorder.unhandledCount())
+	})
+})
@@ -0,0 +1,130 @@
+package Promise
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Backoff returns how long to wait before the next attempt, given the number
+// of attempts which failed so far (starting at 1).
+type Backoff func(failedAttempts int) time.Duration
+
+// ConstantBackoff waits the same delay before every attempt.
+func ConstantBackoff(delay time.Duration) Backoff {
+	return func(failedAttempts int) time.Duration {
+		return delay
+	}
+}
+
+// ExponentialBackoff waits initial before the second attempt and doubles the
+// delay before every following attempt, up to max. A max of zero means the
+// delay is not limited.
+func ExponentialBackoff(initial time.Duration, max time.Duration) Backoff {
+	return func(failedAttempts int) time.Duration {
+		delay := initial
+		for i := 1; i < failedAttempts && delay < math.MaxInt64/2; i++ {
+			delay *= 2
+		}
+		if max > 0 && delay > max {
+			return max
+		}
+		return delay
+	}
+}
+
+// JitteredBackoff waits a random delay between zero and the delay of the
+// given backoff ("full jitter"), so clients which failed together do not
+// retry together.
+func JitteredBackoff(backoff Backoff) Backoff {
+	return func(failedAttempts int) time.Duration {
+		delay := backoff(failedAttempts)
+		if delay <= 0 {
+			return 0
+		}
+		return time.Duration(rand.Int63n(int64(delay)))
+	}
+}
+
+// RetryPolicy configures Retry. The zero value retries every error
+// immediately and without a limit.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of promises created by the factory,
+	// zero means no limit
+	MaxAttempts int
+	// Backoff is the delay before each retry, nil means no delay
+	Backoff Backoff
+	// Retryable decides whether an error is worth another attempt, nil means
+	// every error is
+	Retryable func(err error) bool
+	// Clock is used to wait for the backoff delay, nil means DefaultClock().
+	// Attempts without a delay do not wait for the clock.
+	Clock Clock
+}
+
+// Retry calls factory and returns a promise which is resolved with the value
+// of the promise returned by factory. When that promise is rejected (or
+// factory panics), factory is called again after the backoff delay of the
+// policy, until an attempt succeeds, the error is not retryable or the policy
+// ran out of attempts. In the last two cases the returned promise is rejected
+// with the error of the last attempt. A panic in the Retryable or Backoff of
+// the policy rejects it with a *PanicError.
+func Retry(factory func() Promise, policy RetryPolicy) Promise {
+	clock := policy.Clock
+	if clock == nil {
+		clock = DefaultClock()
+	}
+	return NewPromise(func(resolve func(interface{}), reject func(error)) {
+		failedAttempts := 0
+		var attempt func()
+		attempt = func() {
+			result := callSafely(func() interface{} {
+				return factory()
+			})
+			promise, isPromise := result.(Promise)
+			if err, isError := result.(error); isError {
+				promise = Reject(err)
+			} else if !isPromise || promise == nil {
+				promise = Reject(fmt.Errorf("Retry factory returned %T instead of a Promise", result))
+			}
+			ThenOrCatch(promise, func(value interface{}) interface{} {
+				resolve(value)
+				return nil
+			}, func(err error) interface{} {
+				failedAttempts++
+				if policy.MaxAttempts > 0 && failedAttempts >= policy.MaxAttempts {
+					reject(err)
+					return nil
+				}
+				// A panic in Retryable or Backoff rejects with the *PanicError
+				next := callSafely(func() interface{} {
+					if policy.Retryable != nil && !policy.Retryable(err) {
+						return err
+					}
+					var delay time.Duration
+					if policy.Backoff != nil {
+						delay = policy.Backoff(failedAttempts)
+					}
+					return delay
+				})
+				delay, retry := next.(time.Duration)
+				if !retry {
+					failure, _ := next.(error)
+					reject(failure)
+					return nil
+				}
+				if delay <= 0 {
+					// The next attempt is dispatched like a callback so a
+					// factory which keeps failing does not grow the stack,
+					// and a fake clock does not need to be advanced
+					DefaultScheduler().Schedule(attempt)
+					return nil
+				}
+				clock.AfterFunc(delay, attempt)
+				return nil
+			})
+		}
+		attempt()
+	})
+}
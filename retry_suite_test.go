@@ -0,0 +1,192 @@
+package Promise
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("Retry", func() {
+	var t = GinkgoT()
+	BeforeEach(func() {
+		t = GinkgoT()
+	})
+
+	// failingFactory returns a factory whose first promises are rejected
+	failingFactory := func(failures int, calls *int) func() Promise {
+		return func() Promise {
+			*calls++
+			if *calls <= failures {
+				return Reject(fmt.Errorf("failure %v", *calls))
+			}
+			return Resolve("foo")
+		}
+	}
+
+	It("should resolve with the value of the first attempt which succeeds", func() {
+		clock := NewFakeClock(time.Now())
+		calls := 0
+		var result interface{}
+		Retry(failingFactory(2, &calls), RetryPolicy{Clock: clock, Backoff: ConstantBackoff(time.Second)}).Then(func(value interface{}) interface{} {
+			result = value
+			return nil
+		})
+		assert.Equal(t, 1, calls)
+		clock.Advance(time.Second)
+		assert.Equal(t, 2, calls)
+		assert.Nil(t, result)
+		clock.Advance(time.Second)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, "foo", result)
+	})
+
+	It("should not wait before retrying until the backoff delay elapsed", func() {
+		clock := NewFakeClock(time.Now())
+		calls := 0
+		Retry(failingFactory(1, &calls), RetryPolicy{Clock: clock, Backoff: ConstantBackoff(time.Second)})
+		clock.Advance(999 * time.Millisecond)
+		assert.Equal(t, 1, calls)
+		clock.Advance(time.Millisecond)
+		assert.Equal(t, 2, calls)
+	})
+
+	It("should reject with the last error when it runs out of attempts", func() {
+		clock := NewFakeClock(time.Now())
+		calls := 0
+		var result error
+		Retry(failingFactory(5, &calls), RetryPolicy{Clock: clock, MaxAttempts: 3}).Catch(func(err error) interface{} {
+			result = err
+			return nil
+		})
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, "failure 3", result.Error())
+		assert.Equal(t, 0, clock.PendingTimers())
+	})
+
+	It("should not retry an error which is not retryable", func() {
+		fatal := fmt.Errorf("fatal")
+		clock := NewFakeClock(time.Now())
+		calls := 0
+		var result error
+		Retry(func() Promise {
+			calls++
+			if calls == 2 {
+				return Reject(fatal)
+			}
+			return Reject(fmt.Errorf("temporary"))
+		}, RetryPolicy{Clock: clock, Retryable: func(err error) bool {
+			return !errors.Is(err, fatal)
+		}}).Catch(func(err error) interface{} {
+			result = err
+			return nil
+		})
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, 0, clock.PendingTimers())
+		assert.Equal(t, fatal, result)
+	})
+
+	It("should retry when the factory panics", func() {
+		clock := NewFakeClock(time.Now())
+		calls := 0
+		var result interface{}
+		Retry(func() Promise {
+			calls++
+			if calls == 1 {
+				panic("Oh no")
+			}
+			return Resolve("foo")
+		}, RetryPolicy{Clock: clock}).Then(func(value interface{}) interface{} {
+			result = value
+			return nil
+		})
+		assert.Equal(t, "foo", result)
+		assert.Equal(t, 0, clock.PendingTimers())
+	})
+
+	It("should reject when Retryable panics", func() {
+		calls := 0
+		_, err := Await(Retry(failingFactory(5, &calls), RetryPolicy{MaxAttempts: 3, Retryable: func(err error) bool {
+			panic("Oh no")
+		}}))
+		var panicError *PanicError
+		assert.True(t, errors.As(err, &panicError))
+		assert.Equal(t, "Oh no", panicError.Value)
+		assert.Equal(t, 1, calls)
+	})
+
+	It("should reject when Backoff panics", func() {
+		calls := 0
+		_, err := Await(Retry(failingFactory(5, &calls), RetryPolicy{MaxAttempts: 3, Backoff: func(failedAttempts int) time.Duration {
+			panic("Oh no")
+		}}))
+		var panicError *PanicError
+		assert.True(t, errors.As(err, &panicError))
+		assert.Equal(t, "Oh no", panicError.Value)
+		assert.Equal(t, 1, calls)
+	})
+
+	It("should retry a backoff without a delay without waiting for the clock", func() {
+		clock := NewFakeClock(time.Now())
+		calls := 0
+		value, err := Await(Retry(failingFactory(2, &calls), RetryPolicy{Clock: clock, Backoff: ConstantBackoff(0)}))
+		assert.Nil(t, err)
+		assert.Equal(t, "foo", value)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, 0, clock.PendingTimers())
+	})
+
+	It("should wait the delays of the backoff", func() {
+		clock := NewFakeClock(time.Now())
+		calls := 0
+		Retry(failingFactory(4, &calls), RetryPolicy{Clock: clock, Backoff: ExponentialBackoff(time.Second, 5*time.Second)})
+		for _, delay := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+			clock.Advance(delay - time.Millisecond)
+			previousCalls := calls
+			clock.Advance(time.Millisecond)
+			assert.Equal(t, previousCalls+1, calls)
+		}
+		assert.Equal(t, 5, calls)
+	})
+
+	It("should work with the real clock", func() {
+		calls := 0
+		value, err := Await(Retry(failingFactory(2, &calls), RetryPolicy{Backoff: ConstantBackoff(time.Millisecond)}))
+		assert.Nil(t, err)
+		assert.Equal(t, "foo", value)
+	})
+
+	Describe("Backoff", func() {
+		It("should return the same delay for ConstantBackoff", func() {
+			backoff := ConstantBackoff(time.Second)
+			assert.Equal(t, time.Second, backoff(1))
+			assert.Equal(t, time.Second, backoff(10))
+		})
+
+		It("should double the delay for ExponentialBackoff", func() {
+			backoff := ExponentialBackoff(time.Second, 0)
+			assert.Equal(t, time.Second, backoff(1))
+			assert.Equal(t, 2*time.Second, backoff(2))
+			assert.Equal(t, 8*time.Second, backoff(4))
+			assert.True(t, backoff(1000) > 0)
+		})
+
+		It("should not exceed the max delay for ExponentialBackoff", func() {
+			backoff := ExponentialBackoff(time.Second, 3*time.Second)
+			assert.Equal(t, 2*time.Second, backoff(2))
+			assert.Equal(t, 3*time.Second, backoff(3))
+			assert.Equal(t, 3*time.Second, backoff(100))
+		})
+
+		It("should return a delay up to the delay of the wrapped backoff for JitteredBackoff", func() {
+			backoff := JitteredBackoff(ConstantBackoff(time.Second))
+			for i := 0; i < 100; i++ {
+				delay := backoff(1)
+				assert.True(t, delay >= 0 && delay < time.Second)
+			}
+			assert.Equal(t, time.Duration(0), JitteredBackoff(ConstantBackoff(0))(1))
+		})
+	})
+})
@@ -0,0 +1,116 @@
+package Promise
+
+import "sync"
+
+// Scheduler decides when and on which goroutine the Then, Catch and Finally
+// callbacks of a promise are called once the promise is settled.
+type Scheduler interface {
+	Schedule(task func())
+}
+
+// SchedulerFunc adapts an ordinary function to the Scheduler interface.
+type SchedulerFunc func(task func())
+
+func (f SchedulerFunc) Schedule(task func()) {
+	f(task)
+}
+
+// SyncScheduler calls every callback immediately on the goroutine which
+// settled the promise or registered the callback. This was the behavior before
+// schedulers were introduced and it makes tests deterministic, but it does not
+// follow A+ 2.2.4 and long chains grow the stack.
+var SyncScheduler Scheduler = SchedulerFunc(func(task func()) {
+	task()
+})
+
+// GoroutineScheduler calls every callback on a new goroutine. Callbacks of the
+// same promise may run concurrently and in any order.
+var GoroutineScheduler Scheduler = SchedulerFunc(func(task func()) {
+	go task()
+})
+
+// MicrotaskScheduler is the default scheduler. It is a single queue, shared by
+// all the promises that use it, whose callbacks are called one at a time in
+// the order they were scheduled.
+var MicrotaskScheduler Scheduler = NewMicrotaskScheduler()
+
+type microtaskScheduler struct {
+	mutex   sync.Mutex
+	tasks   []func()
+	running bool
+}
+
+// NewMicrotaskScheduler creates a queue similar to the JavaScript microtask
+// queue. Tasks run one at a time, in order, on a goroutine which is started
+// when tasks are scheduled and exits when the queue is empty. A task must
+// not block waiting for another task of the same queue (for example by
+// calling Await inside a Then callback) or the queue is deadlocked.
+func NewMicrotaskScheduler() Scheduler {
+	return &microtaskScheduler{}
+}
+
+func (m *microtaskScheduler) Schedule(task func()) {
+	m.mutex.Lock()
+	m.tasks = append(m.tasks, task)
+	if m.running {
+		m.mutex.Unlock()
+		return
+	}
+	m.running = true
+	m.mutex.Unlock()
+	go m.drain()
+}
+
+func (m *microtaskScheduler) drain() {
+	for {
+		m.mutex.Lock()
+		if len(m.tasks) == 0 {
+			m.running = false
+			m.mutex.Unlock()
+			return
+		}
+		task := m.tasks[0]
+		m.tasks[0] = nil
+		m.tasks = m.tasks[1:]
+		m.mutex.Unlock()
+		task()
+	}
+}
+
+var defaultSchedulerMutex sync.RWMutex
+var defaultScheduler = MicrotaskScheduler
+
+// SetDefaultScheduler sets the scheduler used by promises which were not
+// created with a scheduler of their own and returns the previous one.
+func SetDefaultScheduler(scheduler Scheduler) Scheduler {
+	defaultSchedulerMutex.Lock()
+	defer defaultSchedulerMutex.Unlock()
+	previous := defaultScheduler
+	defaultScheduler = scheduler
+	return previous
+}
+
+// DefaultScheduler returns the scheduler used by promises which were not
+// created with a scheduler of their own.
+func DefaultScheduler() Scheduler {
+	defaultSchedulerMutex.RLock()
+	defer defaultSchedulerMutex.RUnlock()
+	return defaultScheduler
+}
+
+// NewPromiseWithScheduler is like NewPromise but the callbacks of the promise,
+// and of every promise derived from it with Then, Catch, Finally and
+// ThenOrCatch, are dispatched by the given scheduler.
+func NewPromiseWithScheduler(scheduler Scheduler, callback func(resolve func(interface{}), reject func(error))) Promise {
+	result := defaultPromise()
+	result.scheduler = scheduler
+	result.execute(callback)
+	return result
+}
+
+func (p *promise) currentScheduler() Scheduler {
+	if p.scheduler != nil {
+		return p.scheduler
+	}
+	return DefaultScheduler()
+}
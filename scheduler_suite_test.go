@@ -0,0 +1,178 @@
+package Promise
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// manualScheduler queues tasks until the spec runs them.
+type manualScheduler struct {
+	mutex sync.Mutex
+	tasks []func()
+}
+
+func (m *manualScheduler) Schedule(task func()) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.tasks = append(m.tasks, task)
+}
+
+func (m *manualScheduler) runAll() {
+	for {
+		m.mutex.Lock()
+		if len(m.tasks) == 0 {
+			m.mutex.Unlock()
+			return
+		}
+		task := m.tasks[0]
+		m.tasks = m.tasks[1:]
+		m.mutex.Unlock()
+		task()
+	}
+}
+
+var _ = Describe("Scheduler", func() {
+	var t = GinkgoT()
+	BeforeEach(func() {
+		t = GinkgoT()
+	})
+
+	Describe("NewPromiseWithScheduler", func() {
+		It("should not call callbacks before the scheduler runs them", func() {
+			scheduler := &manualScheduler{}
+			done := false
+			NewPromiseWithScheduler(scheduler, func(resolve func(interface{}), reject func(error)) {
+				resolve("foo")
+			}).Then(func(i interface{}) interface{} {
+				assert.Equal(t, "foo", i)
+				done = true
+				return nil
+			})
+			assert.False(t, done)
+			scheduler.runAll()
+			assert.True(t, done)
+		})
+
+		It("should use the scheduler for derived promises", func() {
+			scheduler := &manualScheduler{}
+			var result interface{}
+			NewPromiseWithScheduler(scheduler, func(resolve func(interface{}), reject func(error)) {
+				resolve(1)
+			}).Then(func(i interface{}) interface{} {
+				return fmt.Errorf("Error")
+			}).Catch(func(err error) interface{} {
+				return 2
+			}).Finally(func() error {
+				return nil
+			}).Then(func(i interface{}) interface{} {
+				result = i
+				return nil
+			})
+			assert.Nil(t, result)
+			scheduler.runAll()
+			assert.Equal(t, 2, result)
+		})
+	})
+
+	Describe("MicrotaskScheduler", func() {
+		It("should call the callback of a resolved promise asynchronously", func() {
+			called := int32(0)
+			derived := NewPromiseWithScheduler(NewMicrotaskScheduler(), func(resolve func(interface{}), reject func(error)) {
+				resolve("foo")
+			})
+			// Hold the queue so the callback cannot run before the assertion
+			blocker := make(chan bool)
+			derived.Then(func(i interface{}) interface{} {
+				<-blocker
+				return nil
+			})
+			next := derived.Then(func(i interface{}) interface{} {
+				atomic.StoreInt32(&called, 1)
+				return i
+			})
+			assert.Equal(t, int32(0), atomic.LoadInt32(&called))
+			close(blocker)
+			value, err := Await(next)
+			assert.Nil(t, err)
+			assert.Equal(t, "foo", value)
+			assert.Equal(t, int32(1), atomic.LoadInt32(&called))
+		})
+
+		It("should call callbacks in the order they were registered", func() {
+			var resolvePromise func(interface{})
+			promiseInstance := NewPromiseWithScheduler(NewMicrotaskScheduler(), func(resolve func(interface{}), reject func(error)) {
+				resolvePromise = resolve
+			})
+			order := []int{}
+			promises := []Promise{}
+			for i := 0; i < 10; i++ {
+				index := i
+				promises = append(promises, promiseInstance.Then(func(interface{}) interface{} {
+					order = append(order, index)
+					return nil
+				}))
+			}
+			resolvePromise(nil)
+			_, err := Await(All(promises))
+			assert.Nil(t, err)
+			assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, order)
+		})
+
+		It("should resolve a long chain without growing the stack", func() {
+			var resolvePromise func(interface{})
+			current := NewPromiseWithScheduler(NewMicrotaskScheduler(), func(resolve func(interface{}), reject func(error)) {
+				resolvePromise = resolve
+			})
+			for i := 0; i < 10000; i++ {
+				current = current.Then(func(i interface{}) interface{} {
+					return i.(int) + 1
+				})
+			}
+			resolvePromise(0)
+			value, err := Await(current)
+			assert.Nil(t, err)
+			assert.Equal(t, 10000, value)
+		})
+	})
+
+	Describe("GoroutineScheduler", func() {
+		It("should call every callback", func() {
+			promiseInstance := NewPromiseWithScheduler(GoroutineScheduler, func(resolve func(interface{}), reject func(error)) {
+				resolve(1)
+			})
+			var total int32
+			promises := []Promise{}
+			for i := 0; i < 10; i++ {
+				promises = append(promises, promiseInstance.Then(func(i interface{}) interface{} {
+					atomic.AddInt32(&total, int32(i.(int)))
+					return nil
+				}))
+			}
+			_, err := Await(All(promises))
+			assert.Nil(t, err)
+			assert.Equal(t, int32(10), atomic.LoadInt32(&total))
+		})
+	})
+
+	Describe("SetDefaultScheduler", func() {
+		It("should be used by promises without a scheduler", func() {
+			scheduler := &manualScheduler{}
+			previous := SetDefaultScheduler(scheduler)
+			defer SetDefaultScheduler(previous)
+			assert.Equal(t, scheduler, DefaultScheduler())
+
+			done := false
+			Resolve("foo").Then(func(i interface{}) interface{} {
+				done = true
+				return nil
+			})
+			assert.False(t, done)
+			scheduler.runAll()
+			assert.True(t, done)
+		})
+	})
+})
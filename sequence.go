@@ -0,0 +1,35 @@
+package Promise
+
+// Series calls the factories one after the other, each one once the promise
+// returned by the previous one is resolved, and returns a promise which is
+// resolved with their values in order. The promise is rejected with the first
+// error and the remaining factories are not called.
+func Series(factories []func() Promise) Promise {
+	results := make([]interface{}, 0, len(factories))
+	current := Resolve(nil)
+	for _, factory := range factories {
+		innerFactory := factory
+		current = current.Then(func(interface{}) interface{} {
+			return innerFactory()
+		}).Then(func(value interface{}) interface{} {
+			results = append(results, value)
+			return nil
+		})
+	}
+	return current.Then(func(interface{}) interface{} {
+		return results
+	})
+}
+
+// Waterfall calls the steps one after the other, like a chain of Then calls,
+// passing each step the value of the previous one. The first step is called
+// with nil. The promise is resolved with the value of the last step or
+// rejected with the first error, in which case the remaining steps are not
+// called.
+func Waterfall(steps []PromiseResolveCallback) Promise {
+	current := Resolve(nil)
+	for _, step := range steps {
+		current = current.Then(step)
+	}
+	return current
+}
@@ -0,0 +1,119 @@
+package Promise
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("Sequence", func() {
+	var t = GinkgoT()
+	BeforeEach(func() {
+		t = GinkgoT()
+	})
+
+	Describe("Series", func() {
+		It("should call the factories in order and resolve with their values", func() {
+			deferreds := []*Deferred{}
+			factory := func() Promise {
+				deferred := NewDeferred()
+				deferreds = append(deferreds, deferred)
+				return deferred.Promise()
+			}
+			promiseInstance := Series([]func() Promise{factory, factory, factory})
+			assert.Len(t, deferreds, 1)
+			deferreds[0].Resolve("a")
+			assert.Len(t, deferreds, 2)
+			deferreds[1].Resolve("b")
+			assert.Len(t, deferreds, 3)
+			deferreds[2].Resolve("c")
+			value, err := Await(promiseInstance)
+			assert.Nil(t, err)
+			assert.Equal(t, []interface{}{"a", "b", "c"}, value)
+		})
+
+		It("should resolve with an empty slice when there are no factories", func() {
+			value, err := Await(Series([]func() Promise{}))
+			assert.Nil(t, err)
+			assert.Equal(t, []interface{}{}, value)
+		})
+
+		It("should stop at the first rejection", func() {
+			called := false
+			_, err := Await(Series([]func() Promise{
+				func() Promise {
+					return Resolve("a")
+				},
+				func() Promise {
+					return Reject(fmt.Errorf("Oh no"))
+				},
+				func() Promise {
+					called = true
+					return Resolve("c")
+				},
+			}))
+			assert.Equal(t, "Oh no", err.Error())
+			assert.False(t, called)
+		})
+
+		It("should run goroutines one after the other", func() {
+			running := false
+			factory := func() Promise {
+				return Run(func() interface{} {
+					if running {
+						return fmt.Errorf("Overlapped")
+					}
+					running = true
+					defer func() {
+						running = false
+					}()
+					return "done"
+				})
+			}
+			value, err := Await(Series([]func() Promise{factory, factory, factory}))
+			assert.Nil(t, err)
+			assert.Equal(t, []interface{}{"done", "done", "done"}, value)
+		})
+	})
+
+	Describe("Waterfall", func() {
+		It("should pass each value to the next step", func() {
+			value, err := Await(Waterfall([]PromiseResolveCallback{
+				func(i interface{}) interface{} {
+					assert.Nil(t, i)
+					return 1
+				},
+				func(i interface{}) interface{} {
+					return Resolve(i.(int) + 1)
+				},
+				func(i interface{}) interface{} {
+					return i.(int) * 10
+				},
+			}))
+			assert.Nil(t, err)
+			assert.Equal(t, 20, value)
+		})
+
+		It("should resolve with nil when there are no steps", func() {
+			value, err := Await(Waterfall([]PromiseResolveCallback{}))
+			assert.Nil(t, err)
+			assert.Nil(t, value)
+		})
+
+		It("should stop at the first rejection", func() {
+			called := false
+			_, err := Await(Waterfall([]PromiseResolveCallback{
+				func(i interface{}) interface{} {
+					return fmt.Errorf("Oh no")
+				},
+				func(i interface{}) interface{} {
+					called = true
+					return i
+				},
+			}))
+			assert.Equal(t, "Oh no", err.Error())
+			assert.False(t, called)
+		})
+	})
+})
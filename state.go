@@ -0,0 +1,52 @@
+package Promise
+
+// State is the settlement state of a promise.
+type State int
+
+const (
+	// Pending means the promise was neither resolved nor rejected yet, or it
+	// follows another promise which is still pending
+	Pending State = iota
+	// Fulfilled means the promise was resolved with a value
+	Fulfilled
+	// Rejected means the promise was rejected with an error
+	Rejected
+)
+
+func (s State) String() string {
+	switch s {
+	case Pending:
+		return "pending"
+	case Fulfilled:
+		return "fulfilled"
+	case Rejected:
+		return "rejected"
+	}
+	return "unknown"
+}
+
+// State returns the current state of the promise.
+func (p *promise) State() State {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.state
+}
+
+// IsPending reports whether the promise is still pending.
+func (p *promise) IsPending() bool {
+	return p.State() == Pending
+}
+
+// Peek returns the value or the error of the promise without waiting for it.
+// settled is false while the promise is pending.
+func (p *promise) Peek() (value interface{}, err error, settled bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.resolveValue, p.rejectValue, p.state != Pending
+}
+
+// Done returns a channel which is closed when the promise is settled, for use
+// in a select statement.
+func (p *promise) Done() <-chan struct{} {
+	return p.done
+}
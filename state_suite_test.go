@@ -0,0 +1,61 @@
+package Promise
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("State", func() {
+	var t = GinkgoT()
+	BeforeEach(func() {
+		t = GinkgoT()
+	})
+
+	It("should describe a pending promise", func() {
+		promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {})
+		value, err, settled := promiseInstance.Peek()
+		assert.Equal(t, Pending, promiseInstance.State())
+		assert.True(t, promiseInstance.IsPending())
+		assert.Nil(t, value)
+		assert.Nil(t, err)
+		assert.False(t, settled)
+	})
+
+	It("should describe a resolved promise", func() {
+		promiseInstance := Resolve("foo")
+		value, err, settled := promiseInstance.Peek()
+		assert.Equal(t, Fulfilled, promiseInstance.State())
+		assert.False(t, promiseInstance.IsPending())
+		assert.Equal(t, "foo", value)
+		assert.Nil(t, err)
+		assert.True(t, settled)
+	})
+
+	It("should describe a rejected promise", func() {
+		promiseInstance := Reject(fmt.Errorf("Oh no"))
+		value, err, settled := promiseInstance.Peek()
+		assert.Equal(t, Rejected, promiseInstance.State())
+		assert.False(t, promiseInstance.IsPending())
+		assert.Nil(t, value)
+		assert.Equal(t, "Oh no", err.Error())
+		assert.True(t, settled)
+	})
+
+	It("should stay pending while following a pending promise", func() {
+		deferred := NewDeferred()
+		promiseInstance := Resolve(deferred.Promise())
+		assert.True(t, promiseInstance.IsPending())
+		deferred.Resolve("foo")
+		value, _, settled := promiseInstance.Peek()
+		assert.True(t, settled)
+		assert.Equal(t, "foo", value)
+	})
+
+	It("should have a name", func() {
+		assert.Equal(t, "pending", Pending.String())
+		assert.Equal(t, "fulfilled", Fulfilled.String())
+		assert.Equal(t, "rejected", Rejected.String())
+	})
+})
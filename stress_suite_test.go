@@ -0,0 +1,172 @@
+package Promise
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+// These specs are meant to be run with the race detector (go test -race).
+var _ = Describe("Stress", func() {
+	var t = GinkgoT()
+	BeforeEach(func() {
+		t = GinkgoT()
+	})
+
+	const goroutines = 100
+
+	It("should call every Then callback exactly once when registering while resolving", func() {
+		var resolvePromise func(interface{})
+		promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
+			resolvePromise = resolve
+		})
+
+		var calls int32
+		start := make(chan bool)
+		wg := sync.WaitGroup{}
+		wg.Add(goroutines + 1)
+		for i := 0; i < goroutines; i++ {
+			go func() {
+				defer wg.Done()
+				<-start
+				promiseInstance.Then(func(i interface{}) interface{} {
+					assert.Equal(t, "foo", i)
+					atomic.AddInt32(&calls, 1)
+					return nil
+				})
+			}()
+		}
+		go func() {
+			defer wg.Done()
+			<-start
+			resolvePromise("foo")
+		}()
+		close(start)
+		wg.Wait()
+		assert.Equal(t, int32(goroutines), atomic.LoadInt32(&calls))
+	})
+
+	It("should call every Catch callback exactly once when registering while rejecting", func() {
+		var rejectPromise func(error)
+		promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
+			rejectPromise = reject
+		})
+
+		var calls int32
+		start := make(chan bool)
+		wg := sync.WaitGroup{}
+		wg.Add(goroutines + 1)
+		for i := 0; i < goroutines; i++ {
+			go func() {
+				defer wg.Done()
+				<-start
+				promiseInstance.Catch(func(err error) interface{} {
+					assert.Equal(t, "foo", err.Error())
+					atomic.AddInt32(&calls, 1)
+					return nil
+				})
+			}()
+		}
+		go func() {
+			defer wg.Done()
+			<-start
+			rejectPromise(fmt.Errorf("foo"))
+		}()
+		close(start)
+		wg.Wait()
+		assert.Equal(t, int32(goroutines), atomic.LoadInt32(&calls))
+	})
+
+	It("should settle only once when resolved and rejected from many goroutines", func() {
+		var resolvePromise func(interface{})
+		var rejectPromise func(error)
+		promiseInstance := NewPromise(func(resolve func(interface{}), reject func(error)) {
+			resolvePromise = resolve
+			rejectPromise = reject
+		})
+
+		var lateSettles int32
+		previous := SetLateSettleHandler(func(err *LateSettleError) {
+			atomic.AddInt32(&lateSettles, 1)
+		})
+		defer SetLateSettleHandler(previous)
+
+		var calls int32
+		promiseInstance.Then(func(i interface{}) interface{} {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		})
+		promiseInstance.Catch(func(err error) interface{} {
+			atomic.AddInt32(&calls, 1)
+			return nil
+		})
+
+		start := make(chan bool)
+		wg := sync.WaitGroup{}
+		wg.Add(goroutines)
+		for i := 0; i < goroutines; i++ {
+			index := i
+			go func() {
+				defer wg.Done()
+				<-start
+				if index%2 == 0 {
+					resolvePromise(index)
+				} else {
+					rejectPromise(fmt.Errorf("%v", index))
+				}
+			}()
+		}
+		close(start)
+		wg.Wait()
+		assert.Equal(t, int32(goroutines-1), atomic.LoadInt32(&lateSettles))
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	It("should resolve chains built concurrently with Run", func() {
+		var total int32
+		wg := sync.WaitGroup{}
+		wg.Add(goroutines)
+		for i := 0; i < goroutines; i++ {
+			value := i
+			go func() {
+				Run(func() interface{} {
+					return value
+				}).Then(func(i interface{}) interface{} {
+					return Run(func() interface{} {
+						return i.(int) + 1
+					})
+				}).Then(func(i interface{}) interface{} {
+					atomic.AddInt32(&total, int32(i.(int)))
+					wg.Done()
+					return nil
+				})
+			}()
+		}
+		wg.Wait()
+		assert.Equal(t, int32(goroutines*(goroutines+1)/2), atomic.LoadInt32(&total))
+	})
+
+	It("should resolve All over promises settled from many goroutines", func() {
+		promises := make([]Promise, goroutines)
+		for i := 0; i < goroutines; i++ {
+			value := i
+			promises[i] = Run(func() interface{} {
+				return value
+			})
+		}
+
+		doneChan := make(chan []interface{}, 1)
+		All(promises).Then(func(values interface{}) interface{} {
+			doneChan <- values.([]interface{})
+			return nil
+		})
+		results := <-doneChan
+		assert.Len(t, results, goroutines)
+		for i, value := range results {
+			assert.Equal(t, i, value)
+		}
+	})
+})
@@ -0,0 +1,85 @@
+package Promise
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// TimeoutError is the rejection error of a promise returned by WithTimeout or
+// WithDeadline when the source promise was not settled in time.
+type TimeoutError struct {
+	// Timeout is the duration given to WithTimeout, zero for WithDeadline
+	Timeout time.Duration
+	// Deadline is the time at which the promise gave up
+	Deadline time.Time
+}
+
+func (e *TimeoutError) Error() string {
+	if e.Timeout > 0 {
+		return fmt.Sprintf("Promise timed out after %v", e.Timeout)
+	}
+	return fmt.Sprintf("Promise timed out at %v", e.Deadline)
+}
+
+// Unwrap returns context.DeadlineExceeded so a TimeoutError can be handled
+// like the error of a context which timed out.
+func (e *TimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// WithTimeout returns a promise which is resolved or rejected like p, unless
+// p is still pending after the given timeout, in which case it is rejected
+// with a *TimeoutError. If p was created with NewPromiseWithContext or
+// RunContext, the context of its executor is canceled with the
+// *TimeoutError as the cause so the underlying work can stop.
+func WithTimeout(p Promise, timeout time.Duration) Promise {
+	clock := DefaultClock()
+	return withTimer(clock, p, timeout, &TimeoutError{Timeout: timeout, Deadline: clock.Now().Add(timeout)})
+}
+
+// WithDeadline is like WithTimeout but gives up at the given time. A deadline
+// which is not after the time of the clock rejects the promise immediately if
+// p is still pending.
+func WithDeadline(p Promise, deadline time.Time) Promise {
+	clock := DefaultClock()
+	return withTimer(clock, p, deadline.Sub(clock.Now()), &TimeoutError{Deadline: deadline})
+}
+
+func withTimer(clock Clock, p Promise, duration time.Duration, timeoutError *TimeoutError) Promise {
+	result := derivePromise(p)
+	timeout := func() {
+		if !result.tryReject(timeoutError) {
+			return
+		}
+		if source, ok := p.(*promise); ok {
+			source.cancelContext(timeoutError)
+		}
+	}
+	// A time which already passed times out right away instead of waiting
+	// for a clock which may never fire, like a FakeClock, unless p is already
+	// settled and the result follows it
+	var timer Timer
+	if duration > 0 {
+		timer = clock.AfterFunc(duration, timeout)
+	} else if p.IsPending() {
+		timeout()
+	}
+	stopTimer := func() {
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+	// The rejection of p is passed on to the result
+	markHandled(p.Then(func(value interface{}) interface{} {
+		stopTimer()
+		result.tryResolve(value)
+		return nil
+	}))
+	p.Catch(func(err error) interface{} {
+		stopTimer()
+		result.tryReject(err)
+		return nil
+	})
+	return result
+}
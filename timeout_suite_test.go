@@ -0,0 +1,149 @@
+package Promise
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+var _ = Describe("Timeout", func() {
+	var t = GinkgoT()
+	BeforeEach(func() {
+		t = GinkgoT()
+	})
+
+	pendingPromise := func() Promise {
+		return NewPromise(func(resolve func(interface{}), reject func(error)) {})
+	}
+
+	Describe("WithTimeout", func() {
+		It("should resolve like the promise if it resolves in time", func() {
+			value, err := Await(WithTimeout(Run(func() interface{} {
+				return "foo"
+			}), time.Second))
+			assert.Nil(t, err)
+			assert.Equal(t, "foo", value)
+		})
+
+		It("should reject like the promise if it rejects in time", func() {
+			_, err := Await(WithTimeout(Reject(fmt.Errorf("Oh no")), time.Second))
+			assert.Equal(t, "Oh no", err.Error())
+		})
+
+		It("should resolve like a settled promise with a timeout of zero", func() {
+			value, err := Await(WithTimeout(Resolve("foo"), 0))
+			assert.Nil(t, err)
+			assert.Equal(t, "foo", value)
+		})
+
+		It("should reject with a TimeoutError if the promise is still pending", func() {
+			clock := NewFakeClock(time.Now())
+			defer SetDefaultClock(SetDefaultClock(clock))
+			derived := WithTimeout(pendingPromise(), 10*time.Millisecond)
+			clock.Advance(9 * time.Millisecond)
+			assert.Equal(t, Pending, derived.State())
+			clock.Advance(time.Millisecond)
+			_, err := Await(derived)
+			var timeoutError *TimeoutError
+			assert.True(t, errors.As(err, &timeoutError))
+			assert.Equal(t, 10*time.Millisecond, timeoutError.Timeout)
+			assert.Equal(t, "Promise timed out after 10ms", err.Error())
+			assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		})
+
+		It("should cancel the work of a promise created with a context", func() {
+			causes := make(chan error, 1)
+			source := RunContext(context.Background(), func(ctx context.Context) interface{} {
+				<-ctx.Done()
+				causes <- context.Cause(ctx)
+				return "too late"
+			})
+			_, err := Await(WithTimeout(source, 10*time.Millisecond))
+			var timeoutError *TimeoutError
+			assert.True(t, errors.As(err, &timeoutError))
+			assert.Equal(t, timeoutError, <-causes)
+			_, sourceErr := Await(source)
+			assert.Equal(t, timeoutError, sourceErr)
+		})
+
+		It("should not cancel the work with a TimeoutError if the promise settled in time", func() {
+			clock := NewFakeClock(time.Now())
+			defer SetDefaultClock(SetDefaultClock(clock))
+			var workContext context.Context
+			source := NewPromiseWithContext(context.Background(), func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+				workContext = ctx
+				resolve("foo")
+			})
+			value, err := Await(WithTimeout(source, 10*time.Millisecond))
+			assert.Nil(t, err)
+			assert.Equal(t, "foo", value)
+			assert.Equal(t, 0, clock.PendingTimers())
+			clock.Advance(20 * time.Millisecond)
+			assert.Equal(t, context.Canceled, context.Cause(workContext))
+		})
+	})
+
+	Describe("WithDeadline", func() {
+		It("should resolve like the promise if it resolves in time", func() {
+			value, err := Await(WithDeadline(Resolve("foo"), time.Now().Add(time.Second)))
+			assert.Nil(t, err)
+			assert.Equal(t, "foo", value)
+		})
+
+		It("should reject with a TimeoutError if the promise is still pending", func() {
+			clock := NewFakeClock(time.Now())
+			defer SetDefaultClock(SetDefaultClock(clock))
+			deadline := clock.Now().Add(10 * time.Millisecond)
+			derived := WithDeadline(pendingPromise(), deadline)
+			clock.Advance(10 * time.Millisecond)
+			_, err := Await(derived)
+			var timeoutError *TimeoutError
+			assert.True(t, errors.As(err, &timeoutError))
+			assert.Equal(t, deadline, timeoutError.Deadline)
+			assert.Equal(t, time.Duration(0), timeoutError.Timeout)
+		})
+
+		It("should reject immediately if the deadline has passed", func() {
+			_, err := Await(WithDeadline(pendingPromise(), time.Now().Add(-time.Second)))
+			var timeoutError *TimeoutError
+			assert.True(t, errors.As(err, &timeoutError))
+		})
+
+		It("should reject without advancing a fake clock if the deadline has passed", func() {
+			clock := NewFakeClock(time.Now())
+			defer SetDefaultClock(SetDefaultClock(clock))
+			derived := WithDeadline(pendingPromise(), clock.Now().Add(-time.Second))
+			assert.Equal(t, Rejected, derived.State())
+			assert.Equal(t, 0, clock.PendingTimers())
+			_, err := Await(derived)
+			var timeoutError *TimeoutError
+			assert.True(t, errors.As(err, &timeoutError))
+		})
+
+		It("should follow a settled promise if the deadline has passed", func() {
+			clock := NewFakeClock(time.Now())
+			defer SetDefaultClock(SetDefaultClock(clock))
+			value, err := Await(WithDeadline(Resolve("foo"), clock.Now().Add(-time.Second)))
+			assert.Nil(t, err)
+			assert.Equal(t, "foo", value)
+			_, err = Await(WithDeadline(Reject(fmt.Errorf("Oh no")), clock.Now()))
+			assert.Equal(t, "Oh no", err.Error())
+		})
+
+		It("should cancel the context of the promise if the deadline has passed", func() {
+			clock := NewFakeClock(time.Now())
+			defer SetDefaultClock(SetDefaultClock(clock))
+			var executorContext context.Context
+			source := NewPromiseWithContext(context.Background(), func(ctx context.Context, resolve func(interface{}), reject func(error)) {
+				executorContext = ctx
+			})
+			WithDeadline(source, clock.Now())
+			var timeoutError *TimeoutError
+			assert.True(t, errors.As(context.Cause(executorContext), &timeoutError))
+		})
+	})
+})
@@ -0,0 +1,201 @@
+// Package typed provides a generics based Promise[T] on top of the untyped
+// Promise interface of github.com/BorisKozo/gopromise.
+//
+// A typed promise wraps an untyped one, so both can be mixed freely using
+// FromUntyped and ToUntyped. Values of type T that implement error or Promise
+// follow the same rules as in the untyped API (they reject or are chained).
+package typed
+
+import (
+	"fmt"
+	"reflect"
+
+	gopromise "github.com/BorisKozo/gopromise"
+)
+
+// Promise is a promise which is resolved with a value of type T.
+// The zero value is not usable, create promises with NewPromise, Resolve,
+// Reject, Run or FromUntyped.
+type Promise[T any] struct {
+	untyped gopromise.Promise
+}
+
+// Result holds the outcome of a single promise passed to Every.
+type Result[T any] struct {
+	Value T
+	Err   error
+}
+
+func cast[T any](value interface{}) (T, error) {
+	var zero T
+	if value == nil {
+		return zero, nil
+	}
+	typedValue, ok := value.(T)
+	if !ok {
+		return zero, fmt.Errorf("Expected a value of type %v but got %T", reflect.TypeOf((*T)(nil)).Elem(), value)
+	}
+	return typedValue, nil
+}
+
+func NewPromise[T any](callback func(resolve func(T), reject func(error))) Promise[T] {
+	return Promise[T]{untyped: gopromise.NewPromise(func(resolve func(interface{}), reject func(error)) {
+		callback(func(value T) {
+			resolve(value)
+		}, reject)
+	})}
+}
+
+func Resolve[T any](value T) Promise[T] {
+	return Promise[T]{untyped: gopromise.Resolve(value)}
+}
+
+func Reject[T any](err error) Promise[T] {
+	return Promise[T]{untyped: gopromise.Reject(err)}
+}
+
+// FromUntyped adapts an untyped promise. If it resolves with a value which is
+// not a T the returned promise is rejected.
+func FromUntyped[T any](promise gopromise.Promise) Promise[T] {
+	return Promise[T]{untyped: promise.Then(func(value interface{}) interface{} {
+		typedValue, err := cast[T](value)
+		if err != nil {
+			return err
+		}
+		return typedValue
+	})}
+}
+
+// ToUntyped returns the untyped promise backing the given typed promise.
+func ToUntyped[T any](promise Promise[T]) gopromise.Promise {
+	return promise.untyped
+}
+
+func (p Promise[T]) Then(callback func(T) (T, error)) Promise[T] {
+	return Then(p, callback)
+}
+
+func (p Promise[T]) Catch(callback func(error) (T, error)) Promise[T] {
+	return Promise[T]{untyped: p.untyped.Catch(func(err error) interface{} {
+		value, err := callback(err)
+		if err != nil {
+			return err
+		}
+		return value
+	})}
+}
+
+func (p Promise[T]) Finally(callback func() error) Promise[T] {
+	return Promise[T]{untyped: p.untyped.Finally(callback)}
+}
+
+func (p Promise[T]) State() gopromise.State {
+	return p.untyped.State()
+}
+
+func (p Promise[T]) IsPending() bool {
+	return p.untyped.IsPending()
+}
+
+// Done returns a channel which is closed when the promise is settled.
+func (p Promise[T]) Done() <-chan struct{} {
+	return p.untyped.Done()
+}
+
+// Peek returns the value or the error of the promise without waiting for it.
+// settled is false while the promise is pending.
+func (p Promise[T]) Peek() (value T, err error, settled bool) {
+	untypedValue, err, settled := p.untyped.Peek()
+	if err != nil || !settled {
+		return value, err, settled
+	}
+	value, err = cast[T](untypedValue)
+	return value, err, settled
+}
+
+// Then registers a resolve handler which may change the type of the resolved
+// value. Go methods cannot have type parameters, so this is a function rather
+// than a method.
+func Then[T, U any](promise Promise[T], callback func(T) (U, error)) Promise[U] {
+	return Promise[U]{untyped: promise.untyped.Then(func(value interface{}) interface{} {
+		typedValue, err := cast[T](value)
+		if err != nil {
+			return err
+		}
+		nextValue, err := callback(typedValue)
+		if err != nil {
+			return err
+		}
+		return nextValue
+	})}
+}
+
+// Chain registers a resolve handler which returns another promise. The
+// returned promise is resolved or rejected along with it.
+func Chain[T, U any](promise Promise[T], callback func(T) Promise[U]) Promise[U] {
+	return Promise[U]{untyped: promise.untyped.Then(func(value interface{}) interface{} {
+		typedValue, err := cast[T](value)
+		if err != nil {
+			return err
+		}
+		return callback(typedValue).untyped
+	})}
+}
+
+func untypedSlice[T any](promises []Promise[T]) []gopromise.Promise {
+	result := make([]gopromise.Promise, len(promises))
+	for index, promise := range promises {
+		result[index] = promise.untyped
+	}
+	return result
+}
+
+func All[T any](promises []Promise[T]) Promise[[]T] {
+	return Promise[[]T]{untyped: gopromise.All(untypedSlice(promises)).Then(func(values interface{}) interface{} {
+		untypedValues := values.([]interface{})
+		result := make([]T, len(untypedValues))
+		for index, value := range untypedValues {
+			typedValue, err := cast[T](value)
+			if err != nil {
+				return err
+			}
+			result[index] = typedValue
+		}
+		return result
+	})}
+}
+
+func Race[T any](promises []Promise[T]) Promise[T] {
+	return FromUntyped[T](gopromise.Race(untypedSlice(promises)))
+}
+
+// Every resolves when all the given promises are settled. Unlike the untyped
+// version each slot is a Result so a rejection is never confused with a value.
+func Every[T any](promises []Promise[T]) Promise[[]Result[T]] {
+	results := make([]gopromise.Promise, len(promises))
+	for index, promise := range promises {
+		results[index] = gopromise.ThenOrCatch(promise.untyped, func(value interface{}) interface{} {
+			typedValue, err := cast[T](value)
+			return Result[T]{Value: typedValue, Err: err}
+		}, func(err error) interface{} {
+			return Result[T]{Err: err}
+		})
+	}
+	return Then(Promise[[]interface{}]{untyped: gopromise.All(results)}, func(values []interface{}) ([]Result[T], error) {
+		result := make([]Result[T], len(values))
+		for index, value := range values {
+			result[index] = value.(Result[T])
+		}
+		return result, nil
+	})
+}
+
+func Run[T any](fn func() (T, error)) Promise[T] {
+	return Promise[T]{untyped: gopromise.Run(func() interface{} {
+		value, err := fn()
+		if err != nil {
+			return err
+		}
+		return value
+	})}
+}
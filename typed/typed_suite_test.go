@@ -0,0 +1,248 @@
+package typed
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	gopromise "github.com/BorisKozo/gopromise"
+	. "github.com/onsi/ginkgo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTyped(t *testing.T) {
+	// The specs check the outcome right after registering a callback
+	gopromise.SetDefaultScheduler(gopromise.SyncScheduler)
+	RunSpecs(t, "Typed Promise Suite")
+}
+
+var _ = Describe("Typed Promise", func() {
+	var t = GinkgoT()
+	BeforeEach(func() {
+		t = GinkgoT()
+	})
+
+	Describe("Constructors", func() {
+		It("should create a resolved promise", func() {
+			done := false
+			Resolve(12).Then(func(value int) (int, error) {
+				assert.Equal(t, 12, value)
+				done = true
+				return value, nil
+			})
+			assert.True(t, done)
+		})
+
+		It("should create a rejected promise", func() {
+			done := false
+			Reject[int](fmt.Errorf("Error")).Catch(func(err error) (int, error) {
+				assert.Equal(t, "Error", err.Error())
+				done = true
+				return 0, nil
+			})
+			assert.True(t, done)
+		})
+
+		It("should create a new promise", func() {
+			done := false
+			NewPromise(func(resolve func(string), reject func(error)) {
+				resolve("foo")
+			}).Then(func(value string) (string, error) {
+				assert.Equal(t, "foo", value)
+				done = true
+				return value, nil
+			})
+			assert.True(t, done)
+		})
+	})
+
+	Describe("Then", func() {
+		It("should chain typed values", func() {
+			var result string
+			Then(Resolve(2), func(value int) (string, error) {
+				return fmt.Sprintf("%v!", value*2), nil
+			}).Then(func(value string) (string, error) {
+				result = value
+				return value, nil
+			})
+			assert.Equal(t, "4!", result)
+		})
+
+		It("should reject when the callback returns an error", func() {
+			done := false
+			Resolve(2).Then(func(value int) (int, error) {
+				return 0, fmt.Errorf("Bad")
+			}).Then(func(value int) (int, error) {
+				assert.Fail(t, "should not be here")
+				return value, nil
+			}).Catch(func(err error) (int, error) {
+				assert.Equal(t, "Bad", err.Error())
+				done = true
+				return 0, nil
+			})
+			assert.True(t, done)
+		})
+
+		It("should chain a returned promise", func() {
+			var result int
+			Chain(Resolve("foo"), func(value string) Promise[int] {
+				return Resolve(len(value))
+			}).Then(func(value int) (int, error) {
+				result = value
+				return value, nil
+			})
+			assert.Equal(t, 3, result)
+		})
+	})
+
+	Describe("Finally", func() {
+		It("should keep the resolved value", func() {
+			called := false
+			var result int
+			Resolve(5).Finally(func() error {
+				called = true
+				return nil
+			}).Then(func(value int) (int, error) {
+				result = value
+				return value, nil
+			})
+			assert.True(t, called)
+			assert.Equal(t, 5, result)
+		})
+	})
+
+	Describe("Introspection", func() {
+		It("should peek the typed value of a resolved promise", func() {
+			promise := Resolve(5)
+			value, err, settled := promise.Peek()
+			assert.Equal(t, gopromise.Fulfilled, promise.State())
+			assert.False(t, promise.IsPending())
+			assert.True(t, settled)
+			assert.Nil(t, err)
+			assert.Equal(t, 5, value)
+			<-promise.Done()
+		})
+
+		It("should peek a pending promise", func() {
+			promise := NewPromise(func(resolve func(string), reject func(error)) {})
+			value, err, settled := promise.Peek()
+			assert.True(t, promise.IsPending())
+			assert.False(t, settled)
+			assert.Nil(t, err)
+			assert.Equal(t, "", value)
+		})
+	})
+
+	Describe("Adapters", func() {
+		It("should convert an untyped promise", func() {
+			var result string
+			FromUntyped[string](gopromise.Resolve("foo")).Then(func(value string) (string, error) {
+				result = value
+				return value, nil
+			})
+			assert.Equal(t, "foo", result)
+		})
+
+		It("should reject when the untyped value has the wrong type", func() {
+			done := false
+			FromUntyped[string](gopromise.Resolve(12)).Catch(func(err error) (string, error) {
+				assert.Equal(t, "Expected a value of type string but got int", err.Error())
+				done = true
+				return "", nil
+			})
+			assert.True(t, done)
+		})
+
+		It("should resolve nil as the zero value", func() {
+			done := false
+			FromUntyped[*int](gopromise.Resolve(nil)).Then(func(value *int) (*int, error) {
+				assert.Nil(t, value)
+				done = true
+				return value, nil
+			})
+			assert.True(t, done)
+		})
+
+		It("should convert to an untyped promise", func() {
+			done := false
+			ToUntyped(Resolve(12)).Then(func(value interface{}) interface{} {
+				assert.Equal(t, 12, value)
+				done = true
+				return nil
+			})
+			assert.True(t, done)
+		})
+	})
+
+	Describe("All", func() {
+		It("should resolve with a typed slice", func() {
+			var result []int
+			All([]Promise[int]{Resolve(1), Resolve(2)}).Then(func(values []int) ([]int, error) {
+				result = values
+				return values, nil
+			})
+			assert.Equal(t, []int{1, 2}, result)
+		})
+
+		It("should reject if any promise rejects", func() {
+			done := false
+			All([]Promise[int]{Resolve(1), Reject[int](fmt.Errorf("Error!"))}).Catch(func(err error) ([]int, error) {
+				assert.Equal(t, "Error!", err.Error())
+				done = true
+				return nil, nil
+			})
+			assert.True(t, done)
+		})
+	})
+
+	Describe("Race", func() {
+		It("should resolve with the first resolved promise", func() {
+			var result int
+			Race([]Promise[int]{Resolve(1), Resolve(2)}).Then(func(value int) (int, error) {
+				result = value
+				return value, nil
+			})
+			assert.Equal(t, 1, result)
+		})
+	})
+
+	Describe("Every", func() {
+		It("should resolve with a result per promise", func() {
+			var result []Result[int]
+			Every([]Promise[int]{Resolve(1), Reject[int](fmt.Errorf("Error!"))}).Then(func(values []Result[int]) ([]Result[int], error) {
+				result = values
+				return values, nil
+			})
+			assert.Len(t, result, 2)
+			assert.Equal(t, 1, result[0].Value)
+			assert.Nil(t, result[0].Err)
+			assert.Equal(t, 0, result[1].Value)
+			assert.Equal(t, "Error!", result[1].Err.Error())
+		})
+	})
+
+	Describe("Run", func() {
+		It("should run an async function and report the result", func() {
+			doneChan := make(chan string, 1)
+			Run(func() (string, error) {
+				time.Sleep(2 * time.Millisecond)
+				return "AAA", nil
+			}).Then(func(value string) (string, error) {
+				doneChan <- value
+				return value, nil
+			})
+			assert.Equal(t, "AAA", <-doneChan)
+		})
+
+		It("should run an async function and reject if there was an error", func() {
+			doneChan := make(chan error, 1)
+			Run(func() (int, error) {
+				return 0, fmt.Errorf("Oh no")
+			}).Catch(func(err error) (int, error) {
+				doneChan <- err
+				return 0, nil
+			})
+			assert.Equal(t, "Oh no", (<-doneChan).Error())
+		})
+	})
+})
@@ -1,15 +1,23 @@
 package Promise
 
-import "sync"
+import (
+  "fmt"
+  "sync"
+)
 
 func ThenOrCatch(promise Promise, resolveHandler PromiseResolveCallback, rejectHandler PromiseRejectCallback) Promise {
-  return NewPromise(func(resolve func(interface{}), reject func(error)) {
-    promise.Then(func(value interface{}) interface{} {
-      resolve(resolveHandler(value))
+  return newDerivedPromise(promise, func(resolve func(interface{}), reject func(error)) {
+    // The rejection is passed on to rejectHandler
+    markHandled(promise.Then(func(value interface{}) interface{} {
+      resolve(callSafely(func() interface{} {
+        return resolveHandler(value)
+      }))
       return nil
-    })
+    }))
     promise.Catch(func(value error) interface{} {
-      resolve(rejectHandler(value))
+      resolve(callSafely(func() interface{} {
+        return rejectHandler(value)
+      }))
       return nil
     })
   })
@@ -117,10 +125,230 @@ func Every(promises []Promise) Promise {
   })
 }
 
+func Any(promises []Promise) Promise {
+  return NewPromise(func(resolve func(interface{}), reject func(error)) {
+    total := len(promises)
+    errs := make([]error, total)
+    if total == 0 {
+      reject(&AggregateError{Errors: errs})
+      return
+    }
+    count := 0
+    anyResolved := false
+    mutex := sync.Mutex{}
+    for index, promise := range promises {
+      innerIndex := index
+      ThenOrCatch(promise, func(value interface{}) interface{} {
+        mutex.Lock()
+        if anyResolved {
+          mutex.Unlock()
+          return nil
+        }
+        anyResolved = true
+        mutex.Unlock()
+        resolve(value)
+        return nil
+      }, func(err error) interface{} {
+        mutex.Lock()
+        errs[innerIndex] = err
+        count++
+        allRejected := count == total
+        mutex.Unlock()
+        if allRejected {
+          reject(&AggregateError{Errors: errs})
+        }
+        return nil
+      })
+    }
+  })
+}
+
+// Some resolves with a []interface{} of the first n values to be fulfilled,
+// in the order they were fulfilled. It rejects with an *AggregateError of the
+// errors, in the order of the given promises, as soon as too many promises were
+// rejected for n of them to be fulfilled.
+func Some(promises []Promise, n int) Promise {
+  return NewPromise(func(resolve func(interface{}), reject func(error)) {
+    total := len(promises)
+    errs := make([]error, total)
+    // A promise may be rejected with nil so errs cannot tell which were
+    rejected := make([]bool, total)
+    rejectSome := func() {
+      rejectedErrs := []error{}
+      for index, err := range errs {
+        if rejected[index] {
+          rejectedErrs = append(rejectedErrs, err)
+        }
+      }
+      reject(&AggregateError{
+        Errors:  rejectedErrs,
+        Message: fmt.Sprintf("Cannot fulfill %v of %v promises", n, total),
+      })
+    }
+    if n <= 0 {
+      resolve([]interface{}{})
+      return
+    }
+    if n > total {
+      rejectSome()
+      return
+    }
+    values := make([]interface{}, 0, n)
+    rejectedCount := 0
+    done := false
+    mutex := sync.Mutex{}
+    for index, promise := range promises {
+      innerIndex := index
+      ThenOrCatch(promise, func(value interface{}) interface{} {
+        mutex.Lock()
+        if done {
+          mutex.Unlock()
+          return nil
+        }
+        values = append(values, value)
+        done = len(values) == n
+        mutex.Unlock()
+        if done {
+          resolve(values)
+        }
+        return nil
+      }, func(err error) interface{} {
+        mutex.Lock()
+        if done {
+          mutex.Unlock()
+          return nil
+        }
+        errs[innerIndex] = err
+        rejected[innerIndex] = true
+        rejectedCount++
+        done = rejectedCount > total-n
+        mutex.Unlock()
+        if done {
+          rejectSome()
+        }
+        return nil
+      })
+    }
+  })
+}
+
+// SettledResult describes the outcome of one promise given to AllSettled.
+// Status is Fulfilled with the resolved Value or Rejected with Err.
+type SettledResult struct {
+  Status State
+  Value  interface{}
+  Err    error
+}
+
+func AllSettled(promises []Promise) Promise {
+  return NewPromise(func(resolve func(interface{}), reject func(error)) {
+    total := len(promises)
+    results := make([]SettledResult, total)
+    if total == 0 {
+      resolve(results)
+      return
+    }
+    count := 0
+    mutex := sync.Mutex{}
+    settle := func(index int, result SettledResult) {
+      mutex.Lock()
+      results[index] = result
+      count++
+      equalLen := count == total
+      mutex.Unlock()
+      if equalLen {
+        resolve(results)
+      }
+    }
+    for index, promise := range promises {
+      innerIndex := index
+      ThenOrCatch(promise, func(value interface{}) interface{} {
+        settle(innerIndex, SettledResult{Status: Fulfilled, Value: value})
+        return nil
+      }, func(err error) interface{} {
+        settle(innerIndex, SettledResult{Status: Rejected, Err: err})
+        return nil
+      })
+    }
+  })
+}
+
+// AllMap is like All for a map of promises. The promise resolves with a
+// map[string]interface{} of the values under the keys of their promises or
+// rejects with the first error.
+func AllMap(promises map[string]Promise) Promise {
+  return NewPromise(func(resolve func(interface{}), reject func(error)) {
+    total := len(promises)
+    result := make(map[string]interface{}, total)
+    if total == 0 {
+      resolve(result)
+      return
+    }
+    hadError := false
+    mutex := sync.Mutex{}
+    for key, promise := range promises {
+      innerKey := key
+      ThenOrCatch(promise, func(value interface{}) interface{} {
+        mutex.Lock()
+        result[innerKey] = value
+        equalLen := len(result) == total
+        mutex.Unlock()
+        if equalLen {
+          resolve(result)
+        }
+        return nil
+      }, func(err error) interface{} {
+        mutex.Lock()
+        if !hadError {
+          hadError = true
+          mutex.Unlock()
+          reject(err)
+        } else {
+          mutex.Unlock()
+        }
+        return nil
+      })
+    }
+  })
+}
+
+// AllSettledMap is like AllSettled for a map of promises. The promise resolves
+// with a map[string]SettledResult under the keys of the promises.
+func AllSettledMap(promises map[string]Promise) Promise {
+  return NewPromise(func(resolve func(interface{}), reject func(error)) {
+    total := len(promises)
+    results := make(map[string]SettledResult, total)
+    if total == 0 {
+      resolve(results)
+      return
+    }
+    mutex := sync.Mutex{}
+    settle := func(key string, result SettledResult) {
+      mutex.Lock()
+      results[key] = result
+      equalLen := len(results) == total
+      mutex.Unlock()
+      if equalLen {
+        resolve(results)
+      }
+    }
+    for key, promise := range promises {
+      innerKey := key
+      ThenOrCatch(promise, func(value interface{}) interface{} {
+        settle(innerKey, SettledResult{Status: Fulfilled, Value: value})
+        return nil
+      }, func(err error) interface{} {
+        settle(innerKey, SettledResult{Status: Rejected, Err: err})
+        return nil
+      })
+    }
+  })
+}
+
 func Run(fn func() interface{}) Promise {
   return NewPromise(func(resolve func(interface{}), reject func(error)) {
     go func() {
-      result := fn()
+      result := callSafely(fn)
       err, ok := result.(error)
       if ok {
         reject(err)
@@ -3,8 +3,9 @@ package Promise
 import (
   . "github.com/onsi/ginkgo"
   "github.com/stretchr/testify/assert"
+  "errors"
   "fmt"
-  "time"
+  "sync/atomic"
 )
 
 var _ = Describe("Util", func() {
@@ -234,43 +235,302 @@ var _ = Describe("Util", func() {
     })
   })
 
+  Describe("Any", func() {
+    It("should resolve with the first resolved promise even if the others reject", func() {
+      promise1 := Reject(fmt.Errorf("Error!"))
+      promise2 := Resolve(2)
+      promise3 := Resolve(3)
+      done := false
+      Any([]Promise{promise1, promise2, promise3}).Then(func(value interface{}) interface{} {
+        assert.Equal(t, 2, value)
+        done = true
+        return nil
+      }).Catch(func(err error) interface{} {
+        assert.Fail(t, "should not be here")
+        return nil
+      })
+      assert.True(t, done)
+    })
+
+    It("should reject with an AggregateError if all promises reject", func() {
+      first := fmt.Errorf("first")
+      second := fmt.Errorf("second")
+      var rejectFirst func(error)
+      promise1 := NewPromise(func(resolve func(interface{}), reject func(error)) {
+        rejectFirst = reject
+      })
+      promise2 := Reject(second)
+      done := false
+      Any([]Promise{promise1, promise2}).Then(func(value interface{}) interface{} {
+        assert.Fail(t, "should not be here")
+        return nil
+      }).Catch(func(err error) interface{} {
+        var aggregateError *AggregateError
+        assert.True(t, errors.As(err, &aggregateError))
+        assert.Equal(t, []error{first, second}, aggregateError.Errors)
+        assert.True(t, errors.Is(err, first))
+        assert.True(t, errors.Is(err, second))
+        assert.Equal(t, "All promises were rejected: [first, second]", err.Error())
+        done = true
+        return nil
+      })
+      assert.False(t, done)
+      rejectFirst(first)
+      assert.True(t, done)
+    })
+
+    It("should reject with a printable AggregateError if promises reject with nil", func() {
+      _, err := Await(Any([]Promise{Reject(nil), Reject(fmt.Errorf("Error!"))}))
+      var aggregateError *AggregateError
+      assert.True(t, errors.As(err, &aggregateError))
+      assert.Equal(t, []error{nil, fmt.Errorf("Error!")}, aggregateError.Errors)
+      assert.Equal(t, "All promises were rejected: [<nil>, Error!]", err.Error())
+    })
+
+    It("should reject if no promises are passed", func() {
+      done := false
+      Any([]Promise{}).Catch(func(err error) interface{} {
+        assert.Len(t, err.(*AggregateError).Errors, 0)
+        done = true
+        return nil
+      })
+      assert.True(t, done)
+    })
+  })
+
+  Describe("Some", func() {
+    It("should resolve with the first n values in the order they were fulfilled", func() {
+      var resolvers []func(interface{})
+      promises := []Promise{}
+      for i := 0; i < 3; i++ {
+        promises = append(promises, NewPromise(func(resolve func(interface{}), reject func(error)) {
+          resolvers = append(resolvers, resolve)
+        }))
+      }
+      var result interface{}
+      Some(promises, 2).Then(func(values interface{}) interface{} {
+        result = values
+        return nil
+      })
+      resolvers[2]("c")
+      assert.Nil(t, result)
+      resolvers[0]("a")
+      assert.Equal(t, []interface{}{"c", "a"}, result)
+      resolvers[1]("b")
+      assert.Equal(t, []interface{}{"c", "a"}, result)
+    })
+
+    It("should resolve when enough promises are fulfilled despite rejections", func() {
+      value, err := Await(Some([]Promise{Reject(fmt.Errorf("Error!")), Resolve(1), Resolve(2)}, 2))
+      assert.Nil(t, err)
+      assert.Equal(t, []interface{}{1, 2}, value)
+    })
+
+    It("should reject as soon as n fulfilled values are impossible", func() {
+      var resolvePromise func(interface{})
+      pending := NewPromise(func(resolve func(interface{}), reject func(error)) {
+        resolvePromise = resolve
+      })
+      promiseInstance := Some([]Promise{Reject(fmt.Errorf("Error 1")), pending, Reject(fmt.Errorf("Error 2"))}, 2)
+      assert.Equal(t, Rejected, promiseInstance.State())
+      _, err := Await(promiseInstance)
+      var aggregateError *AggregateError
+      assert.True(t, errors.As(err, &aggregateError))
+      assert.Len(t, aggregateError.Errors, 2)
+      assert.Equal(t, "Cannot fulfill 2 of 3 promises: [Error 1, Error 2]", err.Error())
+      resolvePromise("foo")
+      assert.Equal(t, Rejected, promiseInstance.State())
+    })
+
+    It("should keep the nil errors of promises rejected with nil", func() {
+      _, err := Await(Some([]Promise{Reject(nil), Resolve(1)}, 2))
+      var aggregateError *AggregateError
+      assert.True(t, errors.As(err, &aggregateError))
+      assert.Equal(t, []error{nil}, aggregateError.Errors)
+      assert.Equal(t, "Cannot fulfill 2 of 2 promises: [<nil>]", err.Error())
+    })
+
+    It("should reject if there are fewer than n promises", func() {
+      _, err := Await(Some([]Promise{Resolve(1)}, 2))
+      assert.Equal(t, "Cannot fulfill 2 of 1 promises: []", err.Error())
+    })
+
+    It("should resolve with an empty slice if n is zero", func() {
+      value, err := Await(Some([]Promise{}, 0))
+      assert.Nil(t, err)
+      assert.Equal(t, []interface{}{}, value)
+    })
+  })
+
+  Describe("AllSettled", func() {
+    It("should resolve with the outcome of every promise", func() {
+      promise1 := Resolve(1)
+      promise2 := Reject(fmt.Errorf("Error!"))
+      done := false
+      AllSettled([]Promise{promise1, promise2}).Then(func(values interface{}) interface{} {
+        results := values.([]SettledResult)
+        assert.Len(t, results, 2)
+        assert.Equal(t, SettledResult{Status: Fulfilled, Value: 1}, results[0])
+        assert.Equal(t, Rejected, results[1].Status)
+        assert.Nil(t, results[1].Value)
+        assert.Equal(t, "Error!", results[1].Err.Error())
+        done = true
+        return nil
+      }).Catch(func(err error) interface{} {
+        assert.Fail(t, "should not be here")
+        return nil
+      })
+      assert.True(t, done)
+    })
+
+    It("should keep the order of the promises when they settle in the future", func() {
+      var resolvePromise func(interface{})
+      var rejectPromise func(error)
+      promise1 := NewPromise(func(resolve func(interface{}), reject func(error)) {
+        resolvePromise = resolve
+      })
+      promise2 := NewPromise(func(resolve func(interface{}), reject func(error)) {
+        rejectPromise = reject
+      })
+      var results []SettledResult
+      AllSettled([]Promise{promise1, promise2}).Then(func(values interface{}) interface{} {
+        results = values.([]SettledResult)
+        return nil
+      })
+      rejectPromise(fmt.Errorf("Error!"))
+      assert.Nil(t, results)
+      resolvePromise("foo")
+      assert.Len(t, results, 2)
+      assert.Equal(t, "foo", results[0].Value)
+      assert.Equal(t, Rejected, results[1].Status)
+    })
+
+    It("should resolve if no promises are passed", func() {
+      done := false
+      AllSettled([]Promise{}).Then(func(values interface{}) interface{} {
+        assert.Len(t, values.([]SettledResult), 0)
+        done = true
+        return nil
+      })
+      assert.True(t, done)
+    })
+  })
+
+  Describe("AllMap", func() {
+    It("should resolve with the values under the keys of their promises", func() {
+      var resolveOrders func(interface{})
+      orders := NewPromise(func(resolve func(interface{}), reject func(error)) {
+        resolveOrders = resolve
+      })
+      var result interface{}
+      AllMap(map[string]Promise{
+        "user":     Resolve("foo"),
+        "orders":   orders,
+        "settings": Resolve(3),
+      }).Then(func(values interface{}) interface{} {
+        result = values
+        return nil
+      })
+      assert.Nil(t, result)
+      resolveOrders([]int{1, 2})
+      assert.Equal(t, map[string]interface{}{"user": "foo", "orders": []int{1, 2}, "settings": 3}, result)
+    })
+
+    It("should reject with the first error only once", func() {
+      var rejectPromise func(error)
+      promise2 := NewPromise(func(resolve func(interface{}), reject func(error)) {
+        rejectPromise = reject
+      })
+      count := 0
+      AllMap(map[string]Promise{
+        "a": Reject(fmt.Errorf("Error!")),
+        "b": promise2,
+      }).Catch(func(err error) interface{} {
+        assert.Equal(t, "Error!", err.Error())
+        count++
+        return nil
+      })
+      rejectPromise(fmt.Errorf("Another error"))
+      assert.Equal(t, 1, count)
+    })
+
+    It("should resolve if no promises are passed", func() {
+      value, err := Await(AllMap(map[string]Promise{}))
+      assert.Nil(t, err)
+      assert.Equal(t, map[string]interface{}{}, value)
+    })
+
+    It("should resolve promises settled by goroutines", func() {
+      promises := map[string]Promise{}
+      for i := 0; i < 20; i++ {
+        index := i
+        promises[fmt.Sprint(index)] = Run(func() interface{} {
+          return index
+        })
+      }
+      value, err := Await(AllMap(promises))
+      assert.Nil(t, err)
+      assert.Len(t, value, 20)
+      assert.Equal(t, 7, value.(map[string]interface{})["7"])
+    })
+  })
+
+  Describe("AllSettledMap", func() {
+    It("should resolve with the outcome of every promise under its key", func() {
+      value, err := Await(AllSettledMap(map[string]Promise{
+        "a": Resolve(1),
+        "b": Reject(fmt.Errorf("Error!")),
+      }))
+      assert.Nil(t, err)
+      results := value.(map[string]SettledResult)
+      assert.Len(t, results, 2)
+      assert.Equal(t, SettledResult{Status: Fulfilled, Value: 1}, results["a"])
+      assert.Equal(t, Rejected, results["b"].Status)
+      assert.Equal(t, "Error!", results["b"].Err.Error())
+    })
+
+    It("should resolve if no promises are passed", func() {
+      value, err := Await(AllSettledMap(map[string]Promise{}))
+      assert.Nil(t, err)
+      assert.Equal(t, map[string]SettledResult{}, value)
+    })
+  })
+
   Describe("Run", func() {
     It("should run an async function and report the result", func() {
-      endChan := make(chan bool)
-      done := 1
-      Run(func() interface{} {
-        time.Sleep(2 * time.Millisecond)
-        done = 2
-        endChan <- true
+      startChan := make(chan bool)
+      done := int32(1)
+      promiseInstance := Run(func() interface{} {
+        <-startChan
+        atomic.StoreInt32(&done, 2)
         return "AAA"
       }).Then(func(i interface{}) interface{} {
         assert.Equal(t, i, "AAA")
-        done = 3
+        atomic.StoreInt32(&done, 3)
         return nil
       })
-      assert.Equal(t, 1, done)
-      <-endChan
-      time.Sleep(2 * time.Millisecond)
-      assert.Equal(t, 3, done)
+      assert.Equal(t, int32(1), atomic.LoadInt32(&done))
+      close(startChan)
+      Await(promiseInstance)
+      assert.Equal(t, int32(3), atomic.LoadInt32(&done))
     })
 
     It("should run an async function and reject if there was an error", func() {
-      endChan := make(chan bool)
-      done := 1
-      Run(func() interface{} {
-        time.Sleep(2 * time.Millisecond)
-        done = 2
-        endChan <- true
+      startChan := make(chan bool)
+      done := int32(1)
+      promiseInstance := Run(func() interface{} {
+        <-startChan
+        atomic.StoreInt32(&done, 2)
         return fmt.Errorf("Oh no")
       }).Catch(func(i error) interface{} {
         assert.Equal(t, i.Error(), "Oh no")
-        done = 3
+        atomic.StoreInt32(&done, 3)
         return nil
       })
-      assert.Equal(t, 1, done)
-      <-endChan
-      time.Sleep(2 * time.Millisecond)
-      assert.Equal(t, 3, done)
+      assert.Equal(t, int32(1), atomic.LoadInt32(&done))
+      close(startChan)
+      Await(promiseInstance)
+      assert.Equal(t, int32(3), atomic.LoadInt32(&done))
     })
   })
 })